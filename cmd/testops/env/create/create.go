@@ -19,6 +19,7 @@ const (
 	slugFlag        = "slug"
 	hostFlag        = "host"
 	outputFlag      = "output"
+	ifNotExistsFlag = "if-not-exists"
 )
 
 // Command returns a new cobra command for create environments
@@ -29,6 +30,7 @@ func Command() *cobra.Command {
 		slug        string
 		host        string
 		output      string
+		ifNotExists bool
 	)
 
 	cmd := &cobra.Command{
@@ -46,7 +48,7 @@ func Command() *cobra.Command {
 			c := client.NewClientV1(token)
 			s := env.NewService(c)
 
-			e, err := s.CreateEnvironment(cmd.Context(), project, title, description, slug, host)
+			e, existed, err := s.CreateEnvironment(cmd.Context(), project, title, description, slug, host, !ifNotExists)
 			if err != nil {
 				return fmt.Errorf("failed to create environment: %w", err)
 			}
@@ -64,6 +66,11 @@ func Command() *cobra.Command {
 				return fmt.Errorf("failed to write environament slug to file: %w", err)
 			}
 
+			if existed {
+				slog.Info(fmt.Sprintf("Environment already exists with slug: %s", e.Slug))
+				return nil
+			}
+
 			slog.Info(fmt.Sprintf("Environment created with slug: %s", e.Slug))
 
 			return nil
@@ -83,6 +90,7 @@ func Command() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&host, hostFlag, "", "host of the environment")
 	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the environment ID")
+	cmd.Flags().BoolVar(&ifNotExists, ifNotExistsFlag, true, "return the environment with the same slug instead of creating a new one, --if-not-exists=false always creates it")
 
 	return cmd
 }
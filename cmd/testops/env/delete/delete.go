@@ -0,0 +1,50 @@
+package delete
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/env"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	slugFlag = "slug"
+)
+
+// Command returns a new cobra command for delete environments
+func Command() *cobra.Command {
+	var slug string
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Short:   "Delete an environment",
+		Example: "qasectl testops env delete --slug staging --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := env.NewService(c)
+
+			if err := s.DeleteEnvironment(cmd.Context(), project, slug); err != nil {
+				return fmt.Errorf("failed to delete environment %s: %w", slug, err)
+			}
+
+			slog.Info(fmt.Sprintf("Environment %s deleted", slug))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&slug, slugFlag, "s", "", "slug of the environment")
+	err := cmd.MarkFlagRequired(slugFlag)
+	if err != nil {
+		slog.Error("failed to mark slug flag required", "error", err)
+	}
+
+	return cmd
+}
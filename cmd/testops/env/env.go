@@ -2,6 +2,10 @@ package env
 
 import (
 	"github.com/qase-tms/qasectl/cmd/testops/env/create"
+	"github.com/qase-tms/qasectl/cmd/testops/env/delete"
+	"github.com/qase-tms/qasectl/cmd/testops/env/get"
+	"github.com/qase-tms/qasectl/cmd/testops/env/list"
+	"github.com/qase-tms/qasectl/cmd/testops/env/update"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,10 @@ func Command() *cobra.Command {
 	}
 
 	cmd.AddCommand(create.Command())
+	cmd.AddCommand(list.Command())
+	cmd.AddCommand(get.Command())
+	cmd.AddCommand(update.Command())
+	cmd.AddCommand(delete.Command())
 
 	return cmd
 }
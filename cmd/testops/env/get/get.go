@@ -0,0 +1,71 @@
+package get
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/env"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	slugFlag   = "slug"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for get environments
+func Command() *cobra.Command {
+	var (
+		slug   string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Show an environment",
+		Example: "qasectl testops env get --slug staging --output yaml --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := env.NewService(c)
+
+			e, err := s.GetEnvironment(cmd.Context(), project, slug)
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"FIELD", "VALUE"},
+				Rows: [][]string{
+					{"ID", strconv.FormatInt(e.ID, 10)},
+					{"Slug", e.Slug},
+					{"Title", e.Title},
+					{"Description", e.Description},
+					{"Host", e.Host},
+				},
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, e, table)
+		},
+	}
+
+	cmd.Flags().StringVarP(&slug, slugFlag, "s", "", "slug of the environment")
+	err := cmd.MarkFlagRequired(slugFlag)
+	if err != nil {
+		slog.Error("failed to mark slug flag required", "error", err)
+	}
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
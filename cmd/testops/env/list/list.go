@@ -0,0 +1,58 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/env"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for list environments
+func Command() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List environments",
+		Example: "qasectl testops env list --output json --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := env.NewService(c)
+
+			envs, err := s.ListEnvironments(cmd.Context(), project)
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "SLUG", "TITLE", "HOST"},
+				Rows:   make([][]string, 0, len(envs)),
+			}
+			for _, e := range envs {
+				table.Rows = append(table.Rows, []string{strconv.FormatInt(e.ID, 10), e.Slug, e.Title, e.Host})
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, envs, table)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+package update
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/service/env"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	slugFlag        = "slug"
+	titleFlag       = "title"
+	descriptionFlag = "description"
+	newSlugFlag     = "new-slug"
+	hostFlag        = "host"
+)
+
+// Command returns a new cobra command for update environments
+func Command() *cobra.Command {
+	var (
+		slug string
+		u    run.EnvironmentUpdate
+	)
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update the title, description, slug or host of an environment",
+		Example: "qasectl testops env update --slug staging --host staging.example.com --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := env.NewService(c)
+
+			if err := s.UpdateEnvironment(cmd.Context(), project, slug, u); err != nil {
+				return fmt.Errorf("failed to update environment %s: %w", slug, err)
+			}
+
+			slog.Info(fmt.Sprintf("Environment %s updated", slug))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&slug, slugFlag, "s", "", "slug of the environment")
+	err := cmd.MarkFlagRequired(slugFlag)
+	if err != nil {
+		slog.Error("failed to mark slug flag required", "error", err)
+	}
+	cmd.Flags().StringVar(&u.Title, titleFlag, "", "new title of the environment")
+	cmd.Flags().StringVarP(&u.Description, descriptionFlag, "d", "", "new description of the environment")
+	cmd.Flags().StringVar(&u.Slug, newSlugFlag, "", "new slug of the environment, (string without spaces)")
+	cmd.Flags().StringVar(&u.Host, hostFlag, "", "new host of the environment")
+
+	return cmd
+}
@@ -0,0 +1,136 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/fields"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	fileFlag   = "file"
+	pruneFlag  = "prune"
+	dryRunFlag = "dry-run"
+	yesFlag    = "yes"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for apply custom fields
+func Command() *cobra.Command {
+	var (
+		file   string
+		prune  bool
+		dryRun bool
+		yes    bool
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "apply",
+		Short:   "Sync the custom fields of the workspace with a YAML or JSON spec",
+		Example: "qasectl testops field custom apply --file fields.yaml --prune --dry-run --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+
+			o, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return fmt.Errorf("failed to open spec file: %w", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			spec, err := fields.ReadSpec(f)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := fields.NewService(c)
+
+			changes, err := s.PlanCustomFields(cmd.Context(), spec, prune)
+			if err != nil {
+				return err
+			}
+
+			if len(changes) == 0 {
+				slog.Info("Custom fields are up to date")
+				return nil
+			}
+
+			table := output.Table{
+				Header: []string{"ACTION", "ID", "TITLE", "ENTITY", "CHANGES"},
+				Rows:   make([][]string, 0, len(changes)),
+			}
+			for _, ch := range changes {
+				id := ""
+				if ch.Field.ID != 0 {
+					id = strconv.FormatInt(ch.Field.ID, 10)
+				}
+				table.Rows = append(table.Rows, []string{ch.Action, id, ch.Field.Title, ch.Field.Entity, strings.Join(ch.Diff, "; ")})
+			}
+
+			if err := output.Write(cmd.OutOrStdout(), o, changes, table); err != nil {
+				return err
+			}
+
+			if dryRun {
+				slog.Info("Dry run, no changes applied", "changes", len(changes))
+				return nil
+			}
+
+			deletes := 0
+			for _, ch := range changes {
+				if ch.Action == fields.ActionDelete {
+					deletes++
+				}
+			}
+
+			if deletes > 0 && !yes {
+				confirmed, err := output.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), fmt.Sprintf("Delete %d custom fields?", deletes))
+				if errors.Is(err, output.ErrNoTerminal) {
+					return fmt.Errorf("not deleting %d custom fields without confirmation because stdin is not a terminal, pass --%s to apply them in scripts and CI", deletes, yesFlag)
+				}
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					return fmt.Errorf("apply cancelled")
+				}
+			}
+
+			if err := s.ApplyCustomFields(cmd.Context(), changes); err != nil {
+				return fmt.Errorf("failed to apply custom fields: %w", err)
+			}
+
+			slog.Info("Custom fields applied", "changes", len(changes))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, fileFlag, "f", "", "path to the YAML or JSON spec of the custom fields")
+	err := cmd.MarkFlagRequired(fileFlag)
+	if err != nil {
+		slog.Error("Error while marking flag as required", "error", err)
+	}
+
+	cmd.Flags().BoolVar(&prune, pruneFlag, false, "delete the custom fields bound to the projects of the spec that are missing in it")
+	cmd.Flags().BoolVar(&dryRun, dryRunFlag, false, "print the changes without applying them")
+	cmd.Flags().BoolVarP(&yes, yesFlag, "y", false, "delete pruned custom fields without asking for confirmation")
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format of the changes: table, json, yaml, csv")
+
+	return cmd
+}
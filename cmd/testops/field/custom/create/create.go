@@ -0,0 +1,64 @@
+package create
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/fields"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	fileFlag = "file"
+)
+
+// Command returns a new cobra command for create custom fields
+func Command() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create custom fields from a YAML or JSON spec",
+		Example: "qasectl testops field custom create --file fields.yaml --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+
+			f, err := os.Open(file)
+			if err != nil {
+				return fmt.Errorf("failed to open spec file: %w", err)
+			}
+			defer f.Close()
+
+			spec, err := fields.ReadSpec(f)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := fields.NewService(c)
+
+			cfs, err := s.CreateCustomFields(cmd.Context(), spec)
+			if err != nil {
+				return fmt.Errorf("failed to create custom fields: %w", err)
+			}
+
+			for _, cf := range cfs {
+				slog.Info(fmt.Sprintf("Custom field %q created with ID %d", cf.Title, cf.ID))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, fileFlag, "f", "", "path to the YAML or JSON spec of the custom fields")
+	err := cmd.MarkFlagRequired(fileFlag)
+	if err != nil {
+		slog.Error("Error while marking flag as required", "error", err)
+	}
+
+	return cmd
+}
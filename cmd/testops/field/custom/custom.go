@@ -1,7 +1,11 @@
 package custom
 
 import (
+	"github.com/qase-tms/qasectl/cmd/testops/field/custom/apply"
+	"github.com/qase-tms/qasectl/cmd/testops/field/custom/create"
 	"github.com/qase-tms/qasectl/cmd/testops/field/custom/delete"
+	"github.com/qase-tms/qasectl/cmd/testops/field/custom/export"
+	"github.com/qase-tms/qasectl/cmd/testops/field/custom/list"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +17,10 @@ func Command() *cobra.Command {
 		Short: "Manage custom fields",
 	}
 
+	cmd.AddCommand(list.Command())
+	cmd.AddCommand(create.Command())
+	cmd.AddCommand(export.Command())
+	cmd.AddCommand(apply.Command())
 	cmd.AddCommand(delete.Command())
 
 	return cmd
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/fields"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	outputFlag = "output"
+	formatFlag = "format"
+)
+
+// Command returns a new cobra command for export custom fields
+func Command() *cobra.Command {
+	var (
+		output string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export custom fields of the workspace to a spec",
+		Example: "qasectl testops field custom export --output fields.yaml --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+
+			if !slices.Contains(fields.SpecFormats, format) {
+				return fmt.Errorf("unknown spec format %q, allowed formats: %s", format, strings.Join(fields.SpecFormats, ", "))
+			}
+
+			c := client.NewClientV1(token)
+			s := fields.NewService(c)
+
+			cfs, err := s.ListCustomFields(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			spec := fields.ExportSpec(cfs)
+
+			if output == "-" {
+				return fields.WriteSpec(cmd.OutOrStdout(), format, spec)
+			}
+
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+
+			if err := fields.WriteSpec(file, format, spec); err != nil {
+				return err
+			}
+
+			slog.Info(fmt.Sprintf("Custom fields exported to %s", output), "fields", len(spec.Fields))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "-", "output path for the spec, - for stdout")
+	cmd.Flags().StringVar(&format, formatFlag, fields.FormatYAML, fmt.Sprintf("format of the spec: %s", strings.Join(fields.SpecFormats, ", ")))
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+package list
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/fields"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for list custom fields
+func Command() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List custom fields of the workspace",
+		Example: "qasectl testops field custom list --output json --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := fields.NewService(c)
+
+			cfs, err := s.ListCustomFields(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "TITLE", "ENTITY", "TYPE", "OPTIONS", "PROJECTS"},
+				Rows:   make([][]string, 0, len(cfs)),
+			}
+			for _, cf := range cfs {
+				projects := strings.Join(cf.Projects, ", ")
+				if cf.AllProjects {
+					projects = "all"
+				}
+				table.Rows = append(table.Rows, []string{strconv.FormatInt(cf.ID, 10), cf.Title, cf.Entity, cf.Type, strings.Join(cf.Options, ", "), projects})
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, cfs, table)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
@@ -5,9 +5,12 @@ import (
 	"log/slog"
 	"os"
 	"path"
+	"slices"
+	"strings"
 
 	"github.com/qase-tms/qasectl/cmd/flags"
 	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/models/run"
 	"github.com/qase-tms/qasectl/internal/service/filter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,7 +19,17 @@ import (
 const (
 	frameworkFlag = "framework"
 	planIDFlag    = "planID"
+	runIDFlag     = "run-id"
+	statusFlag    = "status"
+	suiteIDFlag   = "suite-id"
+	tagsFlag      = "tags"
+	priorityFlag  = "priority"
+	severityFlag  = "severity"
+	automateFlag  = "automation"
+	queryFlag     = "query"
 	outputFlag    = "output"
+	formatFlag    = "output-format"
+	chunkFlag     = "chunk-size"
 )
 
 // Command returns a new cobra command for upload
@@ -24,13 +37,18 @@ func Command() *cobra.Command {
 	var (
 		framework string
 		planID    int64
+		runID     int64
+		statuses  []string
+		cases     filter.CaseFilter
 		output    string
+		format    string
+		chunkSize int
 	)
 
 	cmd := &cobra.Command{
 		Use:     "filter",
-		Short:   "Get filtered results for the given plan ID and framework",
-		Example: "qasectl testops filter --framework 'playwright' --planID 123 --project 'PRJ' --token 'TOKEN'",
+		Short:   "Get filtered results for the given plan, run or case filters and framework",
+		Example: "qasectl testops filter --framework 'playwright' --run-id 42 --status failed,invalid --project 'PRJ' --token 'TOKEN'",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			const op = "filter"
 			logger := slog.With("op", op)
@@ -38,46 +56,89 @@ func Command() *cobra.Command {
 			token := viper.GetString(flags.TokenFlag)
 			project := viper.GetString(flags.ProjectFlag)
 
+			if !slices.Contains(filter.OutputFormats, format) {
+				return fmt.Errorf("unknown output format %q, allowed formats: %s", format, strings.Join(filter.OutputFormats, ", "))
+			}
+
 			cv1 := client.NewClientV1(token)
 			s := filter.NewService(cv1)
 
-			filteredResults, err := s.GetFilteredResults(cmd.Context(), project, planID, framework)
+			f, err := s.GetFilter(cmd.Context(), project, filter.Source{
+				PlanID:   planID,
+				RunID:    runID,
+				Statuses: statuses,
+				Cases:    cases,
+			}, framework, chunkSize)
 			if err != nil {
 				return err
 			}
 
 			if output == "" {
-				dir, err := os.Getwd()
-				if err != nil {
-					return fmt.Errorf("failed to get current directory: %w", err)
+				switch format {
+				case filter.FormatDotenv, filter.FormatShell:
+					dir, err := os.Getwd()
+					if err != nil {
+						return fmt.Errorf("failed to get current directory: %w", err)
+					}
+					output = path.Join(dir, "qase.env")
+				case filter.FormatGithubOutput:
+					output = os.Getenv("GITHUB_OUTPUT")
+					if output == "" {
+						return fmt.Errorf("GITHUB_OUTPUT is not set, pass --%s to write the output to a file", outputFlag)
+					}
+				default:
+					output = "-"
 				}
-				output = path.Join(dir, "qase.env")
 			}
 
-			err = os.WriteFile(output, []byte(fmt.Sprintf("QASE_FILTERED_RESULTS=%s", filteredResults)), 0644)
+			if output == "-" {
+				return filter.Write(cmd.OutOrStdout(), format, f)
+			}
+
+			// GitHub Actions collects the outputs of all steps in the same file
+			mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			if format == filter.FormatGithubOutput {
+				mode = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+			}
+
+			file, err := os.OpenFile(output, mode, 0644)
 			if err != nil {
+				return fmt.Errorf("failed to open output file: %w", err)
+			}
+			defer func() { _ = file.Close() }()
+
+			if err := filter.Write(file, format, f); err != nil {
 				return fmt.Errorf("failed to write filtered results to file: %w", err)
 			}
 
-			logger.Info(fmt.Sprintf("Filtered results saved to %s", output))
+			logger.Info(fmt.Sprintf("Filtered results saved to %s", output), "cases", len(f.Cases), "chunks", len(f.Filters))
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&framework, frameworkFlag, "f", "", "framework of the results file: playwright")
+	cmd.Flags().StringVarP(&framework, frameworkFlag, "f", "", fmt.Sprintf("framework to prepare the filter for: %s", strings.Join(filter.Frameworks(), ", ")))
 	err := cmd.MarkFlagRequired(frameworkFlag)
 	if err != nil {
 		slog.Error("Error while marking flag as required", "error", err)
 	}
 
 	cmd.Flags().Int64Var(&planID, planIDFlag, 0, "ID of the test plan")
-	err = cmd.MarkFlagRequired(planIDFlag)
-	if err != nil {
-		slog.Error("Error while marking flag as required", "error", err)
-	}
+	cmd.Flags().Int64Var(&runID, runIDFlag, 0, "ID of the test run to take the cases from")
+	cmd.MarkFlagsMutuallyExclusive(planIDFlag, runIDFlag)
+
+	cmd.Flags().StringSliceVar(&statuses, statusFlag, run.DefaultSelectStatuses, "select the cases of the test run whose last result has one of these statuses. format: --status failed,invalid")
+
+	cmd.Flags().Int64Var(&cases.SuiteID, suiteIDFlag, 0, "select the cases of this suite and its nested suites")
+	cmd.Flags().StringSliceVar(&cases.Tags, tagsFlag, []string{}, "select the cases with all of these tags. format: --tags smoke,login")
+	cmd.Flags().StringSliceVar(&cases.Priority, priorityFlag, []string{}, "select the cases with these priorities. format: --priority high,medium")
+	cmd.Flags().StringSliceVar(&cases.Severity, severityFlag, []string{}, "select the cases with these severities. format: --severity blocker,critical")
+	cmd.Flags().StringVar(&cases.Automation, automateFlag, "", "select the cases with this automation status: automated, manual, to-be-automated")
+	cmd.Flags().StringVar(&cases.Query, queryFlag, "", "select the cases whose titles match this search query")
 
-	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the filtered results")
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the filtered results, - for stdout. Default: qase.env for dotenv and shell, $GITHUB_OUTPUT for github-output and stdout for other formats")
+	cmd.Flags().StringVar(&format, formatFlag, filter.FormatDotenv, fmt.Sprintf("output format: %s", strings.Join(filter.OutputFormats, ", ")))
+	cmd.Flags().IntVar(&chunkSize, chunkFlag, 0, "split the filter into chunks of at most this many characters, 0 disables chunking")
 
 	return cmd
 }
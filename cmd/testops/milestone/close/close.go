@@ -0,0 +1,50 @@
+package close
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/milestone"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag = "id"
+)
+
+// Command returns a new cobra command for close milestones
+func Command() *cobra.Command {
+	var id int64
+
+	cmd := &cobra.Command{
+		Use:     "close",
+		Short:   "Mark a milestone as completed",
+		Example: "qasectl testops milestone close --id 1 --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := milestone.NewService(c)
+
+			if err := s.CloseMilestone(cmd.Context(), project, id); err != nil {
+				return fmt.Errorf("failed to close milestone with ID %d: %w", id, err)
+			}
+
+			slog.Info(fmt.Sprintf("Milestone %d closed", id))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&id, idFlag, 0, "ID of the milestone")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+
+	return cmd
+}
@@ -19,6 +19,7 @@ const (
 	statusFlag      = "status"
 	dueDateFlag     = "due-date"
 	outputFlag      = "output"
+	ifNotExistsFlag = "if-not-exists"
 )
 
 // Command returns a new cobra command for create milestones
@@ -29,6 +30,7 @@ func Command() *cobra.Command {
 		status      string
 		dueDate     string
 		output      string
+		ifNotExists bool
 	)
 
 	cmd := &cobra.Command{
@@ -55,7 +57,7 @@ func Command() *cobra.Command {
 			c := client.NewClientV1(token)
 			s := milestone.NewService(c)
 
-			e, err := s.CreateMilestone(cmd.Context(), project, title, description, status, t)
+			e, existed, err := s.CreateMilestone(cmd.Context(), project, title, description, status, t, ifNotExists)
 			if err != nil {
 				return fmt.Errorf("failed to create milestone: %w", err)
 			}
@@ -73,6 +75,11 @@ func Command() *cobra.Command {
 				return fmt.Errorf("failed to write milestone ID to file: %w", err)
 			}
 
+			if existed {
+				slog.Info(fmt.Sprintf("Milestone already exists with ID: %d", e.ID))
+				return nil
+			}
+
 			slog.Info(fmt.Sprintf("Milestone created with ID: %d", e.ID))
 
 			return nil
@@ -88,6 +95,7 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&status, statusFlag, "s", "", "status of the milestone. Allowed values: active, completed")
 	cmd.Flags().StringVar(&dueDate, dueDateFlag, "", "due date of the milestone. Format: YYYY-MM-DD")
 	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the milestone ID")
+	cmd.Flags().BoolVar(&ifNotExists, ifNotExistsFlag, false, "return the ID of the milestone with the same title instead of creating a new one")
 
 	return cmd
 }
@@ -0,0 +1,66 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/milestone"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	statusFlag = "status"
+	searchFlag = "search"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for list milestones
+func Command() *cobra.Command {
+	var (
+		statuses []string
+		search   string
+		format   string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List milestones",
+		Example: "qasectl testops milestone list --status active --search 'Release' --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := milestone.NewService(c)
+
+			mss, err := s.ListMilestones(cmd.Context(), project, search, statuses)
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "TITLE", "STATUS", "DUE DATE"},
+				Rows:   make([][]string, 0, len(mss)),
+			}
+			for _, ms := range mss {
+				table.Rows = append(table.Rows, []string{strconv.FormatInt(ms.ID, 10), ms.Title, ms.Status, output.Time(ms.DueDate)})
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, mss, table)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&statuses, statusFlag, "s", []string{}, "statuses of the milestones: active, completed. format: --status active")
+	cmd.Flags().StringVar(&search, searchFlag, "", "list milestones with titles containing this text")
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
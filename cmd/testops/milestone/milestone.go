@@ -1,7 +1,11 @@
 package milestone
 
 import (
+	"github.com/qase-tms/qasectl/cmd/testops/milestone/close"
 	"github.com/qase-tms/qasectl/cmd/testops/milestone/create"
+	"github.com/qase-tms/qasectl/cmd/testops/milestone/delete"
+	"github.com/qase-tms/qasectl/cmd/testops/milestone/list"
+	"github.com/qase-tms/qasectl/cmd/testops/milestone/update"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,10 @@ func Command() *cobra.Command {
 	}
 
 	cmd.AddCommand(create.Command())
+	cmd.AddCommand(list.Command())
+	cmd.AddCommand(update.Command())
+	cmd.AddCommand(close.Command())
+	cmd.AddCommand(delete.Command())
 
 	return cmd
 }
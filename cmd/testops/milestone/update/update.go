@@ -0,0 +1,60 @@
+package update
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/service/milestone"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag          = "id"
+	titleFlag       = "title"
+	descriptionFlag = "description"
+	statusFlag      = "status"
+)
+
+// Command returns a new cobra command for update milestones
+func Command() *cobra.Command {
+	var (
+		id int64
+		u  run.MilestoneUpdate
+	)
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update the title, description or status of a milestone",
+		Example: "qasectl testops milestone update --id 1 --title 'Release 2.1' --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := milestone.NewService(c)
+
+			if err := s.UpdateMilestone(cmd.Context(), project, id, u); err != nil {
+				return fmt.Errorf("failed to update milestone with ID %d: %w", id, err)
+			}
+
+			slog.Info(fmt.Sprintf("Milestone %d updated", id))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&id, idFlag, 0, "ID of the milestone")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVar(&u.Title, titleFlag, "", "new title of the milestone")
+	cmd.Flags().StringVarP(&u.Description, descriptionFlag, "d", "", "new description of the milestone")
+	cmd.Flags().StringVarP(&u.Status, statusFlag, "s", "", "new status of the milestone. Allowed values: active, completed")
+
+	return cmd
+}
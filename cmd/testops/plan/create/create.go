@@ -0,0 +1,91 @@
+package create
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/service/plan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	titleFlag         = "title"
+	descriptionFlag   = "description"
+	casesFlag         = "cases"
+	casesFromFileFlag = "cases-from-file"
+	fromRunFlag       = "from-run"
+	statusesFlag      = "statuses"
+	outputFlag        = "output"
+)
+
+// Command returns a new cobra command for create plans
+func Command() *cobra.Command {
+	var (
+		p             plan.CreateParams
+		casesFromFile string
+		output        string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a test plan from case IDs, a file or the results of a test run",
+		Example: "qasectl testops plan create --title 'Regression' --from-run 123 --statuses failed --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			if casesFromFile != "" {
+				cases, err := plan.ReadCasesFile(casesFromFile)
+				if err != nil {
+					return err
+				}
+				p.Cases = append(p.Cases, cases...)
+			}
+
+			c := client.NewClientV1(token)
+			s := plan.NewService(c)
+
+			id, err := s.CreatePlan(cmd.Context(), project, p)
+			if err != nil {
+				return fmt.Errorf("failed to create plan: %w", err)
+			}
+
+			if output == "" {
+				dir, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current directory: %w", err)
+				}
+				output = path.Join(dir, "qase.env")
+			}
+
+			err = os.WriteFile(output, []byte(fmt.Sprintf("QASE_TESTOPS_PLAN_ID=%d", id)), 0644)
+			if err != nil {
+				return fmt.Errorf("failed to write plan ID to file: %w", err)
+			}
+
+			slog.Info(fmt.Sprintf("Plan created with ID: %d", id))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&p.Title, titleFlag, "", "title of the test plan")
+	err := cmd.MarkFlagRequired(titleFlag)
+	if err != nil {
+		slog.Error("failed to mark title flag required", "error", err)
+	}
+	cmd.Flags().StringVarP(&p.Description, descriptionFlag, "d", "", "description of the test plan")
+	cmd.Flags().Int64SliceVar(&p.Cases, casesFlag, []int64{}, "IDs of the test cases. format: --cases 1,2,3")
+	cmd.Flags().StringVar(&casesFromFile, casesFromFileFlag, "", "path to a file with the IDs of the test cases, one per line")
+	cmd.Flags().Int64Var(&p.FromRunID, fromRunFlag, 0, "ID of the test run to take the cases from")
+	cmd.Flags().StringSliceVar(&p.Statuses, statusesFlag, run.DefaultSelectStatuses, "add the cases of the test run whose last result has one of these statuses. format: --statuses failed,blocked")
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the test plan ID")
+
+	return cmd
+}
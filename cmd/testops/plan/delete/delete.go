@@ -0,0 +1,50 @@
+package delete
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/plan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag = "id"
+)
+
+// Command returns a new cobra command for delete plans
+func Command() *cobra.Command {
+	var planID int64
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Short:   "Delete a test plan",
+		Example: "qasectl testops plan delete --id 12 --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := plan.NewService(c)
+
+			if err := s.DeletePlan(cmd.Context(), project, planID); err != nil {
+				return fmt.Errorf("failed to delete plan with ID %d: %w", planID, err)
+			}
+
+			slog.Info(fmt.Sprintf("Plan %d deleted", planID))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&planID, idFlag, 0, "ID of the test plan")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+package get
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/plan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag     = "id"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for get plans
+func Command() *cobra.Command {
+	var (
+		planID int64
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Show a test plan with its cases",
+		Example: "qasectl testops plan get --id 12 --output yaml --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := plan.NewService(c)
+
+			p, err := s.GetPlan(cmd.Context(), project, planID)
+			if err != nil {
+				return fmt.Errorf("failed to get plan with ID %d: %w", planID, err)
+			}
+
+			cases := make([]string, 0, len(p.Cases))
+			for _, id := range p.Cases {
+				cases = append(cases, strconv.FormatInt(id, 10))
+			}
+
+			table := output.Table{
+				Header: []string{"FIELD", "VALUE"},
+				Rows: [][]string{
+					{"ID", strconv.FormatInt(p.ID, 10)},
+					{"Title", p.Title},
+					{"Description", p.Description},
+					{"Cases", strings.Join(cases, ",")},
+				},
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, p, table)
+		},
+	}
+
+	cmd.Flags().Int64Var(&planID, idFlag, 0, "ID of the test plan")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
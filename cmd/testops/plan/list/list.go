@@ -0,0 +1,63 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/plan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for list plans
+func Command() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List test plans",
+		Example: "qasectl testops plan list --output json --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := plan.NewService(c)
+
+			plans, err := s.ListPlans(cmd.Context(), project)
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "TITLE", "CASES", "CREATED"},
+				Rows:   make([][]string, 0, len(plans)),
+			}
+			for _, p := range plans {
+				table.Rows = append(table.Rows, []string{
+					strconv.FormatInt(p.ID, 10),
+					p.Title,
+					strconv.Itoa(p.CasesCount),
+					output.Time(p.CreatedAt),
+				})
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, plans, table)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
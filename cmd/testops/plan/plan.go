@@ -0,0 +1,27 @@
+package plan
+
+import (
+	"github.com/qase-tms/qasectl/cmd/testops/plan/create"
+	"github.com/qase-tms/qasectl/cmd/testops/plan/delete"
+	"github.com/qase-tms/qasectl/cmd/testops/plan/get"
+	"github.com/qase-tms/qasectl/cmd/testops/plan/list"
+	"github.com/qase-tms/qasectl/cmd/testops/plan/update"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a new cobra command for plans
+func Command() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Manage test plans",
+	}
+
+	cmd.AddCommand(list.Command())
+	cmd.AddCommand(get.Command())
+	cmd.AddCommand(create.Command())
+	cmd.AddCommand(update.Command())
+	cmd.AddCommand(delete.Command())
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package update
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	models "github.com/qase-tms/qasectl/internal/models/plan"
+	"github.com/qase-tms/qasectl/internal/service/plan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag            = "id"
+	titleFlag         = "title"
+	descriptionFlag   = "description"
+	casesFlag         = "cases"
+	casesFromFileFlag = "cases-from-file"
+)
+
+// Command returns a new cobra command for update plans
+func Command() *cobra.Command {
+	var (
+		planID        int64
+		u             models.Update
+		casesFromFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update the title, description or cases of a test plan",
+		Example: "qasectl testops plan update --id 12 --cases-from-file cases.txt --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			if casesFromFile != "" {
+				cases, err := plan.ReadCasesFile(casesFromFile)
+				if err != nil {
+					return err
+				}
+				u.Cases = append(u.Cases, cases...)
+			}
+
+			c := client.NewClientV1(token)
+			s := plan.NewService(c)
+
+			if err := s.UpdatePlan(cmd.Context(), project, planID, u); err != nil {
+				return fmt.Errorf("failed to update plan with ID %d: %w", planID, err)
+			}
+
+			slog.Info(fmt.Sprintf("Plan %d updated", planID))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&planID, idFlag, 0, "ID of the test plan")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVar(&u.Title, titleFlag, "", "new title of the test plan")
+	cmd.Flags().StringVarP(&u.Description, descriptionFlag, "d", "", "new description of the test plan")
+	cmd.Flags().Int64SliceVar(&u.Cases, casesFlag, []int64{}, "IDs of the test cases replacing the cases of the plan. format: --cases 1,2,3")
+	cmd.Flags().StringVar(&casesFromFile, casesFromFileFlag, "", "path to a file with the IDs of the test cases replacing the cases of the plan, one per line")
+
+	return cmd
+}
@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/ci"
 	"github.com/qase-tms/qasectl/internal/client"
 	"github.com/qase-tms/qasectl/internal/parsers/allure"
 	"github.com/qase-tms/qasectl/internal/parsers/junit"
@@ -13,6 +18,7 @@ import (
 	"github.com/qase-tms/qasectl/internal/parsers/xctest"
 	"github.com/qase-tms/qasectl/internal/service/result"
 	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/qase-tms/qasectl/internal/tmpl"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -26,6 +32,41 @@ const (
 	statusFlag               = "replace-statuses"
 	skipParamsFlag           = "skip-params"
 	attachmentExtensionsFlag = "attachment-extensions"
+	streamFlag               = "stream"
+	includeStatusFlag        = "include-status"
+	excludeStatusFlag        = "exclude-status"
+	includeSuiteFlag         = "include-suite"
+	excludeSuiteFlag         = "exclude-suite"
+	includeTitleFlag         = "include-title"
+	excludeTitleFlag         = "exclude-title"
+	includeSignatureFlag     = "include-signature"
+	excludeSignatureFlag     = "exclude-signature"
+	quarantineFileFlag       = "exclude-signatures-file"
+	includeFieldFlag         = "include-field"
+	excludeFieldFlag         = "exclude-field"
+	rulesFlag                = "rules"
+	fieldFlag                = "field"
+	paramFlag                = "param"
+	tagFlag                  = "tag"
+	overrideValuesFlag       = "override-values"
+	retriesFlag              = "retries"
+	stripTimesFlag           = "strip-times"
+	redactSecretsFlag        = "redact-secrets"
+	redactPatternFlag        = "redact-pattern"
+	maxAttachmentSizeFlag    = "max-attachment-size"
+	maxResultSizeFlag        = "max-result-attachments-size"
+	truncateTextFlag         = "truncate-text-attachments"
+	compressTextOverFlag     = "compress-text-over"
+	maxImageWidthFlag        = "max-image-width"
+	imageQualityFlag         = "image-quality"
+	splitByFlag              = "split-by"
+	splitTitleFlag           = "split-title"
+	ciAnnotateFlag           = "ci-annotations"
+	ciFieldFlag              = "ci-field"
+	reuseRunFlag             = "reuse-run"
+	reuseTagFlag             = "reuse-tag"
+	customFieldFlag          = "custom-field"
+	configFlag               = "configuration"
 )
 
 // Command returns a new cobra command for upload
@@ -42,6 +83,30 @@ func Command() *cobra.Command {
 		status               string
 		skipParams           bool
 		attachmentExtensions string
+		stream               bool
+		filter               result.ResultFilter
+		quarantineFile       string
+		rulesFile            string
+		fields               []string
+		params               []string
+		tags                 []string
+		overrideValues       bool
+		retries              string
+		stripTimes           bool
+		redactSecrets        bool
+		redactPatterns       []string
+		maxAttachmentSize    string
+		maxResultSize        string
+		compressTextOver     string
+		attachmentPolicy     result.AttachmentPolicy
+		splitBy              string
+		splitTitle           string
+		annotations          []string
+		ciFields             map[string]string
+		reuseRun             bool
+		reuseTag             string
+		runFields            map[string]string
+		runConfigs           map[string]string
 	)
 
 	cmd := &cobra.Command{
@@ -63,6 +128,52 @@ func Command() *cobra.Command {
 				}
 			}
 
+			if quarantineFile != "" {
+				signatures, err := readLines(quarantineFile)
+				if err != nil {
+					return fmt.Errorf("failed to read signatures file: %w", err)
+				}
+				filter.ExcludeSignatures = append(filter.ExcludeSignatures, signatures...)
+			}
+
+			var rules []result.Rule
+			if rulesFile != "" {
+				r, err := result.LoadRules(rulesFile)
+				if err != nil {
+					return err
+				}
+				rules = r
+			}
+
+			injectedFields, err := parseKeyValues(fields)
+			if err != nil {
+				return fmt.Errorf("failed to parse fields: %w", err)
+			}
+			injectedParams, err := parseKeyValues(params)
+			if err != nil {
+				return fmt.Errorf("failed to parse params: %w", err)
+			}
+			for i, tag := range tags {
+				tags[i] = os.ExpandEnv(tag)
+			}
+
+			sizes := []struct {
+				flag  string
+				value string
+				dst   *int64
+			}{
+				{maxAttachmentSizeFlag, maxAttachmentSize, &attachmentPolicy.MaxFileSize},
+				{maxResultSizeFlag, maxResultSize, &attachmentPolicy.MaxResultSize},
+				{compressTextOverFlag, compressTextOver, &attachmentPolicy.CompressTextOver},
+			}
+			for _, size := range sizes {
+				v, err := parseSize(size.value)
+				if err != nil {
+					return fmt.Errorf("failed to parse %s: %w", size.flag, err)
+				}
+				*size.dst = v
+			}
+
 			var p result.Parser
 			switch format {
 			case "junit":
@@ -87,6 +198,26 @@ func Command() *cobra.Command {
 			rs := run.NewService(cv1)
 			s := result.NewService(cv2, p, rs)
 
+			info := ci.Detect(os.Getenv)
+			annotation, err := ci.Annotate(info, annotations, ciFields)
+			if err != nil {
+				return err
+			}
+			userFields, err := rs.ResolveCustomFields(cmd.Context(), project, runFields)
+			if err != nil {
+				return err
+			}
+
+			runConfigurations, err := rs.ResolveConfigurations(cmd.Context(), project, runConfigs)
+			if err != nil {
+				return err
+			}
+
+			// the CI description is appended after the description template is rendered
+			_, runTags, runCustomFields := annotation.Apply("", nil, userFields)
+
+			templateData := tmpl.NewData(time.Now(), info)
+
 			param := result.UploadParams{
 				RunID:                runID,
 				Title:                title,
@@ -97,9 +228,30 @@ func Command() *cobra.Command {
 				Statuses:             statuses,
 				SkipParams:           skipParams,
 				AttachmentExtensions: attachmentExtensions,
+				Stream:               stream,
+				Filter:               filter,
+				Rules:                rules,
+				Fields:               injectedFields,
+				Params:               injectedParams,
+				Tags:                 tags,
+				OverrideValues:       overrideValues,
+				Retries:              result.RetryMode(retries),
+				StripTimes:           stripTimes,
+				RedactSecrets:        redactSecrets,
+				RedactPatterns:       redactPatterns,
+				AttachmentPolicy:     attachmentPolicy,
+				SplitBy:              splitBy,
+				SplitTitle:           splitTitle,
+				TemplateData:         &templateData,
+				CIDescription:        annotation.Description,
+				RunTags:              runTags,
+				RunCustomFields:      runCustomFields,
+				RunConfigurations:    runConfigurations,
+				ReuseRun:             reuseRun,
+				ReuseTag:             reuseTag,
 			}
 
-			err := s.Upload(cmd.Context(), param)
+			err = s.Upload(cmd.Context(), param)
 			if err != nil {
 				return err
 			}
@@ -123,8 +275,8 @@ func Command() *cobra.Command {
 	}
 
 	cmd.Flags().Int64Var(&runID, runIDFlag, 0, "ID of the test run")
-	cmd.Flags().StringVar(&title, titleFlag, "", "Title of the test run")
-	cmd.Flags().StringVarP(&description, descriptionFlag, "d", "", "Description of the test run")
+	cmd.Flags().StringVar(&title, titleFlag, "", "Title of the test run. Supports Go templates, e.g. 'Nightly {{.Date}} {{.Branch}}'")
+	cmd.Flags().StringVarP(&description, descriptionFlag, "d", "", "Description of the test run. Supports Go templates")
 	cmd.MarkFlagsOneRequired(runIDFlag, titleFlag)
 	cmd.MarkFlagsMutuallyExclusive(runIDFlag, titleFlag)
 
@@ -134,6 +286,115 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&status, statusFlag, "", "Replace statuses of the results. Pass '{\"Passed\": \"Failed\"}' to replace all passed results with failed")
 	cmd.Flags().BoolVar(&skipParams, skipParamsFlag, false, "Skip parameters for the results")
 	cmd.Flags().StringVar(&attachmentExtensions, attachmentExtensionsFlag, "", "Comma-separated list of file extensions to filter attachments. If not specified, all attachments will be uploaded")
+	cmd.Flags().BoolVar(&stream, streamFlag, false, "Upload results while the report is being parsed to lower memory usage on large reports")
+
+	cmd.Flags().StringSliceVar(&filter.IncludeStatuses, includeStatusFlag, []string{}, "Upload only results with these statuses. format: --include-status passed,failed")
+	cmd.Flags().StringSliceVar(&filter.ExcludeStatuses, excludeStatusFlag, []string{}, "Do not upload results with these statuses. format: --exclude-status skipped")
+	cmd.Flags().StringSliceVar(&filter.IncludeSuites, includeSuiteFlag, []string{}, "Upload only results from suites matching these glob patterns, e.g. 'E2E/*'")
+	cmd.Flags().StringSliceVar(&filter.ExcludeSuites, excludeSuiteFlag, []string{}, "Do not upload results from suites matching these glob patterns")
+	cmd.Flags().StringVar(&filter.IncludeTitle, includeTitleFlag, "", "Upload only results with titles matching this regular expression")
+	cmd.Flags().StringVar(&filter.ExcludeTitle, excludeTitleFlag, "", "Do not upload results with titles matching this regular expression")
+	cmd.Flags().StringSliceVar(&filter.IncludeSignatures, includeSignatureFlag, []string{}, "Upload only results with these signatures")
+	cmd.Flags().StringSliceVar(&filter.ExcludeSignatures, excludeSignatureFlag, []string{}, "Do not upload results with these signatures")
+	cmd.Flags().StringVar(&quarantineFile, quarantineFileFlag, "", "Path to a file with signatures to exclude, one per line. Lines starting with # are ignored")
+	cmd.Flags().StringToStringVar(&filter.IncludeFields, includeFieldFlag, map[string]string{}, "Upload only results with these field values. format: --include-field layer=e2e")
+	cmd.Flags().StringToStringVar(&filter.ExcludeFields, excludeFieldFlag, map[string]string{}, "Do not upload results with any of these field values. format: --exclude-field layer=unit")
+	cmd.Flags().StringVar(&rulesFile, rulesFlag, "", "Path to a YAML or JSON file with transformation rules applied to every result")
+	cmd.Flags().StringArrayVar(&fields, fieldFlag, []string{}, "Set a field on every result. Can be repeated. Environment variables are expanded. format: --field environment=$ENV")
+	cmd.Flags().StringArrayVar(&params, paramFlag, []string{}, "Set a parameter on every result. Can be repeated. Environment variables are expanded. format: --param shard=$CI_NODE_INDEX")
+	cmd.Flags().StringSliceVar(&tags, tagFlag, []string{}, "Add tags to every result. format: --tag nightly,smoke")
+	cmd.Flags().BoolVar(&overrideValues, overrideValuesFlag, false, "Let values from --field and --param override the values from the report")
+	cmd.Flags().StringVar(&retries, retriesFlag, string(result.RetryModeKeepAll), "How to upload retried executions of the same test: keep-all, keep-last, merge")
+	cmd.Flags().BoolVar(&stripTimes, stripTimesFlag, false, "Drop start and end times of the results uploaded to an existing test run")
+	cmd.Flags().BoolVar(&redactSecrets, redactSecretsFlag, false, "Replace JWTs, AWS keys, Authorization headers and credentials in URLs with [REDACTED] before uploading")
+	cmd.Flags().StringArrayVar(&redactPatterns, redactPatternFlag, []string{}, "Replace matches of this regular expression with [REDACTED] before uploading. Can be repeated")
+	cmd.Flags().StringVar(&maxAttachmentSize, maxAttachmentSizeFlag, "", "Skip attachments larger than this size, e.g. 10MB")
+	cmd.Flags().StringVar(&maxResultSize, maxResultSizeFlag, "", "Skip attachments once the attachments of a result exceed this size, e.g. 50MB")
+	cmd.Flags().BoolVar(&attachmentPolicy.TruncateText, truncateTextFlag, false, "Truncate text attachments over the size limits instead of skipping them")
+	cmd.Flags().StringVar(&compressTextOver, compressTextOverFlag, "", "Gzip text attachments larger than this size, e.g. 1MB")
+	cmd.Flags().IntVar(&attachmentPolicy.MaxImageWidth, maxImageWidthFlag, 0, "Downscale PNG and JPEG attachments wider than this number of pixels")
+	cmd.Flags().IntVar(&attachmentPolicy.ImageQuality, imageQualityFlag, 0, "JPEG quality of downscaled images, from 1 to 100. Default: 85")
+	cmd.Flags().StringVar(&splitBy, splitByFlag, "", "Create a test run per parameter value or suite. format: param:browser or suite-level:1")
+	cmd.Flags().StringVar(&splitTitle, splitTitleFlag, result.DefaultSplitTitle, "Title template of the test runs created with --split-by. Available values: {{.Title}}, {{.Value}}")
+	cmd.MarkFlagsMutuallyExclusive(runIDFlag, splitByFlag)
+	cmd.Flags().StringSliceVar(&annotations, ciAnnotateFlag, ci.DefaultAnnotations, "CI metadata added to the created test run when running in CI: tags, description, fields. Pass an empty value to disable")
+	cmd.Flags().StringToStringVar(&ciFields, ciFieldFlag, nil, "Map a CI value to a run custom field ID for the fields annotation, e.g. branch=3")
+	cmd.Flags().BoolVar(&reuseRun, reuseRunFlag, false, "Upload to an active test run with the same title instead of creating a new one. The run is left open, complete it with 'run complete --when-all'")
+	cmd.Flags().StringVar(&reuseTag, reuseTagFlag, "", "Match the reused test run by this tag instead of the title, e.g. pipeline-$CI_PIPELINE_ID. The tag is added to the created run")
+	cmd.MarkFlagsMutuallyExclusive(runIDFlag, reuseRunFlag)
+	cmd.MarkFlagsMutuallyExclusive(splitByFlag, reuseRunFlag)
+	cmd.Flags().StringToStringVar(&runFields, customFieldFlag, map[string]string{}, "Set a custom field of the created test run by ID or title. format: --custom-field Release=1.2.0")
+	cmd.Flags().StringToStringVar(&runConfigs, configFlag, map[string]string{}, "Add a configuration to the created test run by group and title. format: --configuration OS=Linux,Browser=Chrome")
+	cmd.MarkFlagsMutuallyExclusive(runIDFlag, customFieldFlag)
+	cmd.MarkFlagsMutuallyExclusive(runIDFlag, configFlag)
 
 	return cmd
 }
+
+// readLines reads non-empty lines from the file, skipping comments that start with #
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// parseKeyValues parses key=value pairs, expanding environment variables in the values
+func parseKeyValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid value %q, expected key=value", pair)
+		}
+		values[k] = os.ExpandEnv(v)
+	}
+
+	return values, nil
+}
+
+// parseSize parses sizes like 512, 100KB, 10MB or 1GB into bytes. An empty value is zero.
+func parseSize(v string) (int64, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if v == "" {
+		return 0, nil
+	}
+
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	multiplier := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			v = strings.TrimSpace(strings.TrimSuffix(v, u.suffix))
+			multiplier = u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q, expected a number of bytes or a value like 10MB", v)
+	}
+
+	return n * multiplier, nil
+}
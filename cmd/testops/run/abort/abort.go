@@ -0,0 +1,53 @@
+package abort
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag = "id"
+)
+
+// Command returns a new cobra command for abort runs
+func Command() *cobra.Command {
+	var (
+		runID int64
+	)
+
+	cmd := &cobra.Command{
+		Use:     "abort",
+		Short:   "Abort a test run",
+		Example: "qasectl testops run abort --id 123 --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := run.NewService(c)
+
+			err := s.AbortRun(cmd.Context(), project, runID)
+			if err != nil {
+				return fmt.Errorf("failed to abort run with ID %d: %w", runID, err)
+			}
+
+			slog.Info(fmt.Sprintf("Run %d aborted", runID))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&runID, idFlag, 0, "ID of the test run")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+
+	return cmd
+}
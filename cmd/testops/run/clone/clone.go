@@ -0,0 +1,74 @@
+package clone
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag     = "id"
+	titleFlag  = "title"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for clone runs
+func Command() *cobra.Command {
+	var (
+		runID  int64
+		title  string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "clone",
+		Short:   "Create a new test run with the cases and configuration of an existing one",
+		Example: "qasectl testops run clone --id 123 --title 'Nightly rerun' --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := run.NewService(c)
+
+			id, err := s.CloneRun(cmd.Context(), project, runID, title)
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				dir, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current directory: %w", err)
+				}
+				output = path.Join(dir, "qase.env")
+			}
+
+			err = os.WriteFile(output, []byte(fmt.Sprintf("QASE_TESTOPS_RUN_ID=%d", id)), 0644)
+			if err != nil {
+				return fmt.Errorf("failed to write run ID to file: %w", err)
+			}
+
+			slog.Info(fmt.Sprintf("Run %d cloned to run with ID: %d", runID, id))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&runID, idFlag, 0, "ID of the test run to clone")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVar(&title, titleFlag, "", "title of the new test run. Default: the title of the cloned run")
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "output path for the new test run ID")
+
+	return cmd
+}
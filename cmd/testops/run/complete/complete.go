@@ -12,13 +12,17 @@ import (
 )
 
 const (
-	idFlag = "id"
+	idFlag       = "id"
+	whenAllFlag  = "when-all"
+	stateDirFlag = "state-dir"
 )
 
 // Command returns a new cobra command for complete runs
 func Command() *cobra.Command {
 	var (
-		runID int64
+		runID    int64
+		whenAll  int
+		stateDir string
 	)
 
 	cmd := &cobra.Command{
@@ -32,13 +36,28 @@ func Command() *cobra.Command {
 			c := client.NewClientV1(token)
 			s := run.NewService(c)
 
-			err := s.CompleteRun(cmd.Context(), project, runID)
-			if err != nil {
-				return fmt.Errorf("failed to complete run with ID %d: %w", runID, err)
+			if whenAll > 0 {
+				if stateDir == "" {
+					return fmt.Errorf("--%s is required with --%s: finished jobs are counted with files in this directory, so it has to be shared by all jobs, e.g. a volume mounted on every CI runner", stateDirFlag, whenAllFlag)
+				}
+
+				completed, err := s.CompleteRunWhenAll(cmd.Context(), project, runID, whenAll, stateDir)
+				if err != nil {
+					return fmt.Errorf("failed to complete run with ID %d: %w", runID, err)
+				}
+				if !completed {
+					fmt.Printf("Run %v is waiting for other jobs", runID)
+					return nil
+				}
+			} else {
+				err := s.CompleteRun(cmd.Context(), project, runID)
+				if err != nil {
+					return fmt.Errorf("failed to complete run with ID %d: %w", runID, err)
+				}
 			}
 
 			fmt.Printf("Run %v completed", runID)
-			return err
+			return nil
 		},
 	}
 
@@ -47,6 +66,8 @@ func Command() *cobra.Command {
 	if err != nil {
 		slog.Error("failed to mark id flag required", "error", err)
 	}
+	cmd.Flags().IntVar(&whenAll, whenAllFlag, 0, "complete the test run only when this number of jobs have called complete, e.g. the number of CI shards")
+	cmd.Flags().StringVar(&stateDir, stateDirFlag, "", "directory shared by all jobs to count the finished ones, e.g. a volume mounted on every CI runner. Required with --when-all")
 
 	return cmd
 }
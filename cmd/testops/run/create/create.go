@@ -1,15 +1,20 @@
 package create
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"path"
 	"slices"
+	"time"
 
 	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/ci"
 	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/service/env"
 	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/qase-tms/qasectl/internal/tmpl"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -24,6 +29,12 @@ const (
 	tagsFlag        = "tags"
 	isCloudFlag     = "cloud"
 	browserFlag     = "browser"
+	ciAnnotateFlag  = "ci-annotations"
+	ciFieldFlag     = "ci-field"
+	reuseRunFlag    = "reuse-run"
+	reuseTagFlag    = "reuse-tag"
+	customFieldFlag = "custom-field"
+	configFlag      = "configuration"
 )
 
 // Command returns a new cobra command for create runs
@@ -38,6 +49,12 @@ func Command() *cobra.Command {
 		tags        []string
 		isCloud     bool
 		browser     string
+		annotations []string
+		ciFields    map[string]string
+		reuseRun    bool
+		reuseTag    string
+		fields      map[string]string
+		configs     map[string]string
 	)
 
 	var browsers = []string{
@@ -63,7 +80,56 @@ func Command() *cobra.Command {
 				}
 			}
 
-			id, err := s.CreateRun(cmd.Context(), project, title, description, environment, milestone, plan, tags, isCloud, browser, nil)
+			info := ci.Detect(os.Getenv)
+			annotation, err := ci.Annotate(info, annotations, ciFields)
+			if err != nil {
+				return err
+			}
+
+			data := tmpl.NewData(time.Now(), info)
+
+			title, err = tmpl.Render("title", title, data)
+			if err != nil {
+				return err
+			}
+
+			description, err = tmpl.Render("description", description, data)
+			if err != nil {
+				return err
+			}
+
+			userFields, err := s.ResolveCustomFields(cmd.Context(), project, fields)
+			if err != nil {
+				return err
+			}
+
+			configurations, err := s.ResolveConfigurations(cmd.Context(), project, configs)
+			if err != nil {
+				return err
+			}
+
+			if environment != "" {
+				if _, err := env.NewService(c).GetEnvironment(cmd.Context(), project, environment); err != nil {
+					return err
+				}
+			}
+
+			description, tags, customFields := annotation.Apply(description, tags, userFields)
+
+			if reuseTag != "" && !slices.Contains(tags, reuseTag) {
+				tags = append(tags, reuseTag)
+			}
+
+			create := func(ctx context.Context) (int64, error) {
+				return s.CreateRun(ctx, project, title, description, environment, milestone, plan, tags, isCloud, browser, nil, customFields, configurations)
+			}
+
+			var id int64
+			if reuseRun {
+				id, _, err = s.ReuseRun(cmd.Context(), project, title, reuseTag, create)
+			} else {
+				id, err = create(cmd.Context())
+			}
 			if err != nil {
 				return fmt.Errorf("failed to create run: %w", err)
 			}
@@ -87,12 +153,12 @@ func Command() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&title, titleFlag, "", "", "title of the test run")
+	cmd.Flags().StringVarP(&title, titleFlag, "", "", "title of the test run. Supports Go templates, e.g. 'Nightly {{.Date}} {{.Branch}}'")
 	err := cmd.MarkFlagRequired(titleFlag)
 	if err != nil {
 		slog.Error("failed to mark title flag required", "error", err)
 	}
-	cmd.Flags().StringVarP(&description, descriptionFlag, "d", "", "description of the test run")
+	cmd.Flags().StringVarP(&description, descriptionFlag, "d", "", "description of the test run. Supports Go templates")
 	cmd.Flags().StringVarP(&environment, environmentFlag, "e", "", "slug of environment of the test run")
 	cmd.Flags().Int64VarP(&milestone, milestoneFlag, "m", 0, "ID of milestone of the test run")
 	cmd.Flags().Int64Var(&plan, planFlag, 0, "ID of plan of the test run")
@@ -101,6 +167,12 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&isCloud, isCloudFlag, false, "mark the test run as cloud run")
 	cmd.Flags().StringVar(&browser, browserFlag, "", "browser of the cloud run")
 	cmd.MarkFlagsRequiredTogether(isCloudFlag, browserFlag)
+	cmd.Flags().StringSliceVar(&annotations, ciAnnotateFlag, ci.DefaultAnnotations, "CI metadata added to the test run when running in CI: tags, description, fields. Pass an empty value to disable")
+	cmd.Flags().StringToStringVar(&ciFields, ciFieldFlag, nil, "map a CI value to a run custom field ID for the fields annotation, e.g. branch=3")
+	cmd.Flags().StringToStringVar(&fields, customFieldFlag, map[string]string{}, "set a custom field of the test run by ID or title. format: --custom-field Release=1.2.0")
+	cmd.Flags().StringToStringVar(&configs, configFlag, map[string]string{}, "add a configuration to the test run by group and title. format: --configuration OS=Linux,Browser=Chrome")
+	cmd.Flags().BoolVar(&reuseRun, reuseRunFlag, false, "reuse an active test run with the same title instead of creating a new one")
+	cmd.Flags().StringVar(&reuseTag, reuseTagFlag, "", "match the reused test run by this tag instead of the title, e.g. pipeline-$CI_PIPELINE_ID. The tag is added to the created run")
 
 	return cmd
 }
@@ -1,30 +1,48 @@
 package delete
 
 import (
+	"errors"
 	"fmt"
 	"github.com/qase-tms/qasectl/cmd/flags"
 	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
 	"github.com/qase-tms/qasectl/internal/service/run"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	idsFlag       = "ids"
-	allFlag       = "all"
-	startTimeFlag = "start"
-	endTimeFlag   = "end"
+	idsFlag         = "ids"
+	allFlag         = "all"
+	startTimeFlag   = "start"
+	endTimeFlag     = "end"
+	titleFlag       = "title"
+	statusFlag      = "status"
+	tagsFlag        = "tags"
+	olderThanFlag   = "older-than"
+	dryRunFlag      = "dry-run"
+	yesFlag         = "yes"
+	concurrencyFlag = "concurrency"
 )
 
 // Command returns a new cobra command for delete runs
 func Command() *cobra.Command {
 	var (
-		ids       []int64
-		all       bool
-		startTime string
-		endTime   string
+		ids         []int64
+		all         bool
+		startTime   string
+		endTime     string
+		title       string
+		statuses    []string
+		tags        []string
+		olderThan   string
+		dryRun      bool
+		yes         bool
+		concurrency int
 	)
 
 	cmd := &cobra.Command{
@@ -35,14 +53,20 @@ func Command() *cobra.Command {
 			token := viper.GetString(flags.TokenFlag)
 			project := viper.GetString(flags.ProjectFlag)
 
-			var start, end int64 = 0, 0
+			p := run.DeleteParams{
+				IDs:      ids,
+				All:      all,
+				Title:    title,
+				Statuses: statuses,
+				Tags:     tags,
+			}
 
 			if startTime != "" {
 				t, err := time.Parse(time.DateOnly, startTime)
 				if err != nil {
 					return fmt.Errorf("failed to parse start time: %w", err)
 				}
-				start = t.Unix()
+				p.Start = t.Unix()
 			}
 
 			if endTime != "" {
@@ -50,30 +74,100 @@ func Command() *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("failed to parse end time: %w", err)
 				}
-				end = t.Unix()
+				p.End = t.Unix()
+			}
+
+			if olderThan != "" {
+				d, err := parseAge(olderThan)
+				if err != nil {
+					return fmt.Errorf("failed to parse %s: %w", olderThanFlag, err)
+				}
+				p.OlderThan = d
 			}
 
 			c := client.NewClientV1(token)
 			s := run.NewService(c)
 
-			err := s.DeleteRun(cmd.Context(), project, ids, all, start, end)
+			runs, err := s.SelectRuns(cmd.Context(), project, p)
 			if err != nil {
 				return fmt.Errorf("failed to delete test runs: %w", err)
 			}
 
-			slog.Info("Test runs deleted")
+			if len(runs) == 0 {
+				slog.Info("No test runs to delete")
+				return nil
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "TITLE", "STATUS", "STARTED"},
+				Rows:   make([][]string, 0, len(runs)),
+			}
+			for _, r := range runs {
+				table.Rows = append(table.Rows, []string{strconv.FormatInt(r.ID, 10), r.Title, r.Status, output.Time(r.StartTime)})
+			}
+
+			if dryRun || !yes {
+				if err := output.Write(cmd.OutOrStdout(), output.FormatTable, runs, table); err != nil {
+					return err
+				}
+			}
+
+			if dryRun {
+				slog.Info(fmt.Sprintf("Dry run: %d test runs would be deleted", len(runs)))
+				return nil
+			}
 
-			return nil
+			if !yes {
+				confirmed, err := output.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), fmt.Sprintf("Delete %d test runs?", len(runs)))
+				if errors.Is(err, output.ErrNoTerminal) {
+					return fmt.Errorf("not deleting %d test runs without confirmation because stdin is not a terminal, pass --%s to delete them in scripts and CI", len(runs), yesFlag)
+				}
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					return fmt.Errorf("deletion cancelled")
+				}
+			}
+
+			report := s.DeleteRuns(cmd.Context(), project, runs, concurrency)
+
+			slog.Info("Test runs deleted", "deleted", len(report.Deleted), "failed", len(report.Failed))
+
+			return report.Err()
 		},
 	}
 
 	cmd.Flags().Int64SliceVar(&ids, idsFlag, []int64{}, "IDs of test runs to delete. format: --ids 1,2,3")
 	cmd.Flags().BoolVar(&all, allFlag, false, "delete all test runs in the project")
-	cmd.MarkFlagsOneRequired(idsFlag, allFlag)
 	cmd.MarkFlagsMutuallyExclusive(idsFlag, allFlag)
 
 	cmd.Flags().StringVarP(&startTime, startTimeFlag, "s", "", "start date of the test runs. Format: YYYY-MM-DD")
 	cmd.Flags().StringVarP(&endTime, endTimeFlag, "e", "", "end date of the test runs. Format: YYYY-MM-DD")
+	cmd.Flags().StringVar(&title, titleFlag, "", "delete test runs with titles matching this regular expression")
+	cmd.Flags().StringSliceVar(&statuses, statusFlag, []string{}, "delete test runs with these statuses: active, complete, abort. format: --status complete,abort")
+	cmd.Flags().StringSliceVar(&tags, tagsFlag, []string{}, "delete test runs with all of these tags. format: --tags nightly")
+	cmd.Flags().StringVar(&olderThan, olderThanFlag, "", "delete test runs started earlier than this duration ago, e.g. 30d or 12h")
+	cmd.Flags().BoolVar(&dryRun, dryRunFlag, false, "list the test runs that would be deleted without deleting them")
+	cmd.Flags().BoolVarP(&yes, yesFlag, "y", false, "delete without asking for confirmation")
+	cmd.Flags().IntVar(&concurrency, concurrencyFlag, run.DefaultDeleteConcurrency, "number of test runs deleted in parallel")
 
 	return cmd
 }
+
+// parseAge parses durations like 12h or 90m, and days like 30d
+func parseAge(v string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q, expected a value like 30d or 12h", v)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected a value like 30d or 12h", v)
+	}
+	return d, nil
+}
@@ -0,0 +1,92 @@
+package get
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag     = "id"
+	outputFlag = "output"
+)
+
+// Command returns a new cobra command for get runs
+func Command() *cobra.Command {
+	var (
+		runID  int64
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Show a test run with its stats",
+		Example: "qasectl testops run get --id 123 --output yaml --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			c := client.NewClientV1(token)
+			s := run.NewService(c)
+
+			r, err := s.GetRun(cmd.Context(), project, runID)
+			if err != nil {
+				return fmt.Errorf("failed to get run with ID %d: %w", runID, err)
+			}
+
+			plan := ""
+			if r.PlanID != 0 {
+				plan = strconv.FormatInt(r.PlanID, 10)
+			}
+
+			table := output.Table{
+				Header: []string{"FIELD", "VALUE"},
+				Rows: [][]string{
+					{"ID", strconv.FormatInt(r.ID, 10)},
+					{"Title", r.Title},
+					{"Status", r.Status},
+					{"Description", r.Description},
+					{"Environment", r.Environment},
+					{"Milestone", r.Milestone},
+					{"Plan", plan},
+					{"Tags", strings.Join(r.Tags, ",")},
+					{"Started", output.Time(r.StartTime)},
+					{"Ended", output.Time(r.EndTime)},
+					{"Total", strconv.Itoa(r.Stats.Total)},
+					{"Untested", strconv.Itoa(r.Stats.Untested)},
+					{"Passed", strconv.Itoa(r.Stats.Passed)},
+					{"Failed", strconv.Itoa(r.Stats.Failed)},
+					{"Blocked", strconv.Itoa(r.Stats.Blocked)},
+					{"Skipped", strconv.Itoa(r.Stats.Skipped)},
+					{"Retest", strconv.Itoa(r.Stats.Retest)},
+					{"In progress", strconv.Itoa(r.Stats.InProgress)},
+					{"Invalid", strconv.Itoa(r.Stats.Invalid)},
+				},
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, r, table)
+		},
+	}
+
+	cmd.Flags().Int64Var(&runID, idFlag, 0, "ID of the test run")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
@@ -0,0 +1,128 @@
+package list
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	models "github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/output"
+	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	statusFlag      = "status"
+	startTimeFlag   = "start"
+	endTimeFlag     = "end"
+	milestoneFlag   = "milestone"
+	environmentFlag = "environment"
+	tagsFlag        = "tags"
+	searchFlag      = "search"
+	outputFlag      = "output"
+)
+
+var statuses = []string{"active", "complete", "abort"}
+
+// Command returns a new cobra command for list runs
+func Command() *cobra.Command {
+	var (
+		status      []string
+		startTime   string
+		endTime     string
+		milestone   int64
+		environment int64
+		tags        []string
+		search      string
+		format      string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List test runs",
+		Example: "qasectl testops run list --status active --start 2024-01-02 --tags nightly --output json --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			f, err := output.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			for _, st := range status {
+				if !slices.Contains(statuses, st) {
+					return fmt.Errorf("invalid status: %s. allowed statuses: %v", st, statuses)
+				}
+			}
+
+			filter := models.Filter{
+				Search:        search,
+				Status:        strings.Join(status, ","),
+				MilestoneID:   milestone,
+				EnvironmentID: environment,
+				Tags:          tags,
+			}
+
+			if startTime != "" {
+				t, err := time.Parse(time.DateOnly, startTime)
+				if err != nil {
+					return fmt.Errorf("failed to parse start time: %w", err)
+				}
+				filter.FromStartTime = t.Unix()
+			}
+
+			if endTime != "" {
+				t, err := time.Parse(time.DateOnly, endTime)
+				if err != nil {
+					return fmt.Errorf("failed to parse end time: %w", err)
+				}
+				filter.ToStartTime = t.Unix()
+			}
+
+			c := client.NewClientV1(token)
+			s := run.NewService(c)
+
+			runs, err := s.ListRuns(cmd.Context(), project, filter)
+			if err != nil {
+				return err
+			}
+
+			table := output.Table{
+				Header: []string{"ID", "TITLE", "STATUS", "STARTED", "TOTAL", "PASSED", "FAILED", "SKIPPED", "TAGS"},
+				Rows:   make([][]string, 0, len(runs)),
+			}
+			for _, r := range runs {
+				table.Rows = append(table.Rows, []string{
+					strconv.FormatInt(r.ID, 10),
+					r.Title,
+					r.Status,
+					output.Time(r.StartTime),
+					strconv.Itoa(r.Stats.Total),
+					strconv.Itoa(r.Stats.Passed),
+					strconv.Itoa(r.Stats.Failed),
+					strconv.Itoa(r.Stats.Skipped),
+					strings.Join(r.Tags, ","),
+				})
+			}
+
+			return output.Write(cmd.OutOrStdout(), f, runs, table)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&status, statusFlag, []string{}, "statuses of the test runs: active, complete, abort. format: --status active,complete")
+	cmd.Flags().StringVarP(&startTime, startTimeFlag, "s", "", "list test runs started after this date. Format: YYYY-MM-DD")
+	cmd.Flags().StringVarP(&endTime, endTimeFlag, "e", "", "list test runs started before this date. Format: YYYY-MM-DD")
+	cmd.Flags().Int64VarP(&milestone, milestoneFlag, "m", 0, "ID of milestone of the test runs")
+	cmd.Flags().Int64Var(&environment, environmentFlag, 0, "ID of environment of the test runs")
+	cmd.Flags().StringSliceVar(&tags, tagsFlag, []string{}, "list test runs with all of these tags. format: --tags nightly,smoke")
+	cmd.Flags().StringVar(&search, searchFlag, "", "list test runs with titles containing this text")
+	cmd.Flags().StringVarP(&format, outputFlag, "o", string(output.FormatTable), "output format: table, json, yaml, csv")
+
+	return cmd
+}
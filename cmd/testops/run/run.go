@@ -1,9 +1,14 @@
 package run
 
 import (
+	"github.com/qase-tms/qasectl/cmd/testops/run/abort"
+	"github.com/qase-tms/qasectl/cmd/testops/run/clone"
 	"github.com/qase-tms/qasectl/cmd/testops/run/complete"
 	"github.com/qase-tms/qasectl/cmd/testops/run/create"
 	"github.com/qase-tms/qasectl/cmd/testops/run/delete"
+	"github.com/qase-tms/qasectl/cmd/testops/run/get"
+	"github.com/qase-tms/qasectl/cmd/testops/run/list"
+	"github.com/qase-tms/qasectl/cmd/testops/run/update"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +23,11 @@ func Command() *cobra.Command {
 	cmd.AddCommand(create.Command())
 	cmd.AddCommand(complete.Command())
 	cmd.AddCommand(delete.Command())
+	cmd.AddCommand(list.Command())
+	cmd.AddCommand(get.Command())
+	cmd.AddCommand(update.Command())
+	cmd.AddCommand(abort.Command())
+	cmd.AddCommand(clone.Command())
 
 	return cmd
 }
@@ -0,0 +1,73 @@
+package update
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/qase-tms/qasectl/cmd/flags"
+	"github.com/qase-tms/qasectl/internal/client"
+	models "github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/service/run"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	idFlag          = "id"
+	titleFlag       = "title"
+	descriptionFlag = "description"
+	environmentFlag = "environment"
+	milestoneFlag   = "milestone"
+	tagsFlag        = "tags"
+	customFieldFlag = "custom-field"
+)
+
+// Command returns a new cobra command for update runs
+func Command() *cobra.Command {
+	var (
+		runID  int64
+		update models.Update
+	)
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update a test run",
+		Example: "qasectl testops run update --id 123 --title 'Nightly' --tags nightly,web --custom-field 3=main --project 'PRJ' --token 'TOKEN'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := viper.GetString(flags.TokenFlag)
+			project := viper.GetString(flags.ProjectFlag)
+
+			c := client.NewClientV1(token)
+			s := run.NewService(c)
+
+			fields, err := s.ResolveCustomFields(cmd.Context(), project, update.CustomFields)
+			if err != nil {
+				return err
+			}
+			update.CustomFields = fields
+
+			err = s.UpdateRun(cmd.Context(), project, runID, update)
+			if err != nil {
+				return fmt.Errorf("failed to update run with ID %d: %w", runID, err)
+			}
+
+			slog.Info(fmt.Sprintf("Run %d updated", runID))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&runID, idFlag, 0, "ID of the test run")
+	err := cmd.MarkFlagRequired(idFlag)
+	if err != nil {
+		slog.Error("failed to mark id flag required", "error", err)
+	}
+	cmd.Flags().StringVar(&update.Title, titleFlag, "", "new title of the test run")
+	cmd.Flags().StringVarP(&update.Description, descriptionFlag, "d", "", "new description of the test run")
+	cmd.Flags().StringVarP(&update.EnvironmentSlug, environmentFlag, "e", "", "slug of the new environment of the test run")
+	cmd.Flags().Int64VarP(&update.MilestoneID, milestoneFlag, "m", 0, "ID of the new milestone of the test run")
+	cmd.Flags().StringSliceVar(&update.Tags, tagsFlag, []string{}, "new tags of the test run, replacing the current ones")
+	cmd.Flags().StringToStringVar(&update.CustomFields, customFieldFlag, map[string]string{}, "set a custom field of the test run by ID or title. format: --custom-field Release=1.2.0")
+
+	return cmd
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/qase-tms/qasectl/cmd/testops/field"
 	"github.com/qase-tms/qasectl/cmd/testops/filter"
 	"github.com/qase-tms/qasectl/cmd/testops/milestone"
+	"github.com/qase-tms/qasectl/cmd/testops/plan"
 	"github.com/qase-tms/qasectl/cmd/testops/result"
 	"github.com/qase-tms/qasectl/cmd/testops/run"
 	"github.com/spf13/cobra"
@@ -52,6 +53,7 @@ func Command() *cobra.Command {
 	cmd.AddCommand(env.Command())
 	cmd.AddCommand(milestone.Command())
 	cmd.AddCommand(filter.Command())
+	cmd.AddCommand(plan.Command())
 	cmd.AddCommand(field.Command())
 
 	return cmd
@@ -0,0 +1,155 @@
+package ci
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// AnnotateTags adds the provider and the branch as run tags
+	AnnotateTags = "tags"
+	// AnnotateDescription appends a block with commit, pull request and job links to the run description
+	AnnotateDescription = "description"
+	// AnnotateFields fills run custom fields with CI values
+	AnnotateFields = "fields"
+)
+
+// DefaultAnnotations are the annotations added when none are configured
+var DefaultAnnotations = []string{AnnotateTags, AnnotateDescription}
+
+// Annotation holds the provenance added to the runs created in a CI job
+type Annotation struct {
+	Tags        []string
+	Description string
+	// CustomFields maps run custom field IDs to CI values
+	CustomFields map[string]string
+}
+
+// ValueKeys are the keys of the CI values that can be mapped to run custom fields
+var ValueKeys = []string{
+	"provider", "branch", "commit", "commit_url", "repository",
+	"job_url", "pipeline_id", "pull_request", "pull_request_url",
+}
+
+// Values returns the non-empty CI values by key
+func (i Info) Values() map[string]string {
+	all := []string{
+		i.Provider, i.Branch, i.Commit, i.CommitURL, i.Repository,
+		i.JobURL, i.PipelineID, i.PullRequest, i.PullRequestURL,
+	}
+
+	values := make(map[string]string, len(all))
+	for n, v := range all {
+		if v != "" {
+			values[ValueKeys[n]] = v
+		}
+	}
+	return values
+}
+
+// Annotate builds the annotation of the given kinds. fieldIDs maps CI value keys to run custom field IDs
+// and is used by the fields annotation. It returns an empty annotation outside of CI.
+func Annotate(info Info, kinds []string, fieldIDs map[string]string) (Annotation, error) {
+	for key := range fieldIDs {
+		if !slices.Contains(ValueKeys, key) {
+			return Annotation{}, fmt.Errorf("unknown CI value %q, allowed values: %s", key, strings.Join(ValueKeys, ", "))
+		}
+	}
+
+	var a Annotation
+	if info.Provider == "" {
+		return a, nil
+	}
+
+	values := info.Values()
+	for _, kind := range kinds {
+		switch kind {
+		case AnnotateTags:
+			a.Tags = compact(info.Provider, info.Branch)
+		case AnnotateDescription:
+			a.Description = info.description()
+		case AnnotateFields:
+			a.CustomFields = make(map[string]string, len(fieldIDs))
+			for key, id := range fieldIDs {
+				if v, ok := values[key]; ok {
+					a.CustomFields[id] = v
+				}
+			}
+		default:
+			return Annotation{}, fmt.Errorf("unknown CI annotation %q, allowed annotations: %s, %s, %s", kind, AnnotateTags, AnnotateDescription, AnnotateFields)
+		}
+	}
+
+	return a, nil
+}
+
+// Apply merges the annotation into the run options given by the user. The user description comes first,
+// tags are added without duplicates and custom fields given by the user win over CI values.
+func (a Annotation) Apply(description string, tags []string, customFields map[string]string) (string, []string, map[string]string) {
+	if a.Description != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += a.Description
+	}
+
+	merged := slices.Clone(tags)
+	for _, tag := range a.Tags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	if len(a.CustomFields) > 0 {
+		fields := maps.Clone(a.CustomFields)
+		maps.Copy(fields, customFields)
+		customFields = fields
+	}
+
+	return description, merged, customFields
+}
+
+// description renders the provenance block of the run description
+func (i Info) description() string {
+	lines := []string{"CI: " + i.Provider}
+
+	if i.Branch != "" {
+		lines = append(lines, "Branch: "+i.Branch)
+	}
+	if i.Commit != "" {
+		lines = append(lines, "Commit: "+link(i.ShortCommit(), i.CommitURL))
+	}
+	if i.PullRequest != "" || i.PullRequestURL != "" {
+		lines = append(lines, "Pull request: "+link("#"+i.PullRequest, i.PullRequestURL))
+	}
+	if i.JobURL != "" {
+		lines = append(lines, "Job: "+link(i.PipelineID, i.JobURL))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// link formats a markdown link, falling back to the text or the URL when the other is missing
+func link(text, url string) string {
+	switch {
+	case url == "":
+		return text
+	case text == "" || text == "#":
+		return url
+	default:
+		return fmt.Sprintf("[%s](%s)", text, url)
+	}
+}
+
+// compact returns the non-empty values
+func compact(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package ci
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnnotate(t *testing.T) {
+	github := Detect(fixtureEnv(map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_REPOSITORY": "acme/shop",
+		"GITHUB_HEAD_REF":   "feature/cart",
+		"GITHUB_REF":        "refs/pull/42/merge",
+		"GITHUB_SHA":        "0123456789abcdef",
+		"GITHUB_RUN_ID":     "987",
+	}))
+	jenkins := Detect(fixtureEnv(map[string]string{
+		"JENKINS_URL":  "https://ci.acme.io/",
+		"GIT_BRANCH":   "origin/develop",
+		"GIT_COMMIT":   "abc",
+		"BUILD_URL":    "https://ci.acme.io/job/shop/12/",
+		"BUILD_NUMBER": "12",
+	}))
+
+	tests := []struct {
+		name       string
+		info       Info
+		kinds      []string
+		fieldIDs   map[string]string
+		want       Annotation
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:  "outside of ci",
+			info:  Detect(fixtureEnv(nil)),
+			kinds: DefaultAnnotations,
+			want:  Annotation{},
+		},
+		{
+			name:  "github pull request",
+			info:  github,
+			kinds: DefaultAnnotations,
+			want: Annotation{
+				Tags: []string{GitHubActions, "feature/cart"},
+				Description: "CI: github-actions\n" +
+					"Branch: feature/cart\n" +
+					"Commit: [01234567](https://github.com/acme/shop/commit/0123456789abcdef)\n" +
+					"Pull request: [#42](https://github.com/acme/shop/pull/42)\n" +
+					"Job: [987](https://github.com/acme/shop/actions/runs/987)",
+			},
+		},
+		{
+			name:  "jenkins without links",
+			info:  jenkins,
+			kinds: []string{AnnotateDescription},
+			want: Annotation{
+				Description: "CI: jenkins\n" +
+					"Branch: develop\n" +
+					"Commit: abc\n" +
+					"Job: [12](https://ci.acme.io/job/shop/12/)",
+			},
+		},
+		{
+			name:     "custom fields",
+			info:     jenkins,
+			kinds:    []string{AnnotateFields},
+			fieldIDs: map[string]string{"branch": "3", "commit": "4", "pull_request": "5"},
+			want:     Annotation{CustomFields: map[string]string{"3": "develop", "4": "abc"}},
+		},
+		{
+			name:       "unknown annotation",
+			info:       jenkins,
+			kinds:      []string{"labels"},
+			wantErr:    true,
+			errMessage: "unknown CI annotation \"labels\", allowed annotations: tags, description, fields",
+		},
+		{
+			name:       "unknown value",
+			info:       Info{},
+			kinds:      []string{AnnotateFields},
+			fieldIDs:   map[string]string{"build": "3"},
+			wantErr:    true,
+			errMessage: "unknown CI value \"build\", allowed values: provider, branch, commit, commit_url, repository, job_url, pipeline_id, pull_request, pull_request_url",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Annotate(tt.info, tt.kinds, tt.fieldIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Annotate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if err.Error() != tt.errMessage {
+					t.Errorf("Annotate() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Annotate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotation_Apply(t *testing.T) {
+	a := Annotation{
+		Tags:         []string{GitLabCI, "main"},
+		Description:  "CI: gitlab-ci",
+		CustomFields: map[string]string{"3": "main", "4": "abc"},
+	}
+
+	tests := []struct {
+		name             string
+		description      string
+		tags             []string
+		customFields     map[string]string
+		wantDescription  string
+		wantTags         []string
+		wantCustomFields map[string]string
+	}{
+		{
+			name:             "no user values",
+			wantDescription:  "CI: gitlab-ci",
+			wantTags:         []string{GitLabCI, "main"},
+			wantCustomFields: map[string]string{"3": "main", "4": "abc"},
+		},
+		{
+			name:             "user values win",
+			description:      "Nightly regression",
+			tags:             []string{"nightly", "main"},
+			customFields:     map[string]string{"4": "override"},
+			wantDescription:  "Nightly regression\n\nCI: gitlab-ci",
+			wantTags:         []string{"nightly", "main", GitLabCI},
+			wantCustomFields: map[string]string{"3": "main", "4": "override"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description, tags, customFields := a.Apply(tt.description, tt.tags, tt.customFields)
+			if description != tt.wantDescription {
+				t.Errorf("Apply() description = %q, want %q", description, tt.wantDescription)
+			}
+			if !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("Apply() tags = %v, want %v", tags, tt.wantTags)
+			}
+			if !reflect.DeepEqual(customFields, tt.wantCustomFields) {
+				t.Errorf("Apply() custom fields = %v, want %v", customFields, tt.wantCustomFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package ci
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// GitHubActions is the provider name of GitHub Actions
+	GitHubActions = "github-actions"
+	// GitLabCI is the provider name of GitLab CI
+	GitLabCI = "gitlab-ci"
+	// Jenkins is the provider name of Jenkins
+	Jenkins = "jenkins"
+	// AzurePipelines is the provider name of Azure Pipelines
+	AzurePipelines = "azure-pipelines"
+	// CircleCI is the provider name of CircleCI
+	CircleCI = "circleci"
+)
+
+// Info describes the CI job qasectl runs in. Fields unknown to the provider are empty.
+type Info struct {
+	Provider       string
+	Branch         string
+	Commit         string
+	CommitURL      string
+	Repository     string
+	JobURL         string
+	PipelineID     string
+	PullRequest    string
+	PullRequestURL string
+}
+
+// ShortCommit returns the first 8 characters of the commit hash
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 8 {
+		return i.Commit[:8]
+	}
+	return i.Commit
+}
+
+// Detect recognizes the CI provider from environment variables read with getenv.
+// It returns an empty Info outside of a known CI provider.
+func Detect(getenv func(string) string) Info {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return detectGitHub(getenv)
+	case getenv("GITLAB_CI") != "":
+		return detectGitLab(getenv)
+	case getenv("TF_BUILD") != "":
+		return detectAzure(getenv)
+	case getenv("CIRCLECI") != "":
+		return detectCircle(getenv)
+	case getenv("JENKINS_URL") != "":
+		return detectJenkins(getenv)
+	default:
+		return Info{}
+	}
+}
+
+func detectGitHub(getenv func(string) string) Info {
+	repo := ""
+	if getenv("GITHUB_REPOSITORY") != "" {
+		repo = fmt.Sprintf("%s/%s", strings.TrimSuffix(firstOf(getenv("GITHUB_SERVER_URL"), "https://github.com"), "/"), getenv("GITHUB_REPOSITORY"))
+	}
+
+	info := Info{
+		Provider:   GitHubActions,
+		Branch:     firstOf(getenv("GITHUB_HEAD_REF"), getenv("GITHUB_REF_NAME")),
+		Commit:     getenv("GITHUB_SHA"),
+		Repository: repo,
+		PipelineID: getenv("GITHUB_RUN_ID"),
+	}
+
+	if repo != "" {
+		if info.Commit != "" {
+			info.CommitURL = fmt.Sprintf("%s/commit/%s", repo, info.Commit)
+		}
+		if info.PipelineID != "" {
+			info.JobURL = fmt.Sprintf("%s/actions/runs/%s", repo, info.PipelineID)
+		}
+	}
+
+	// pull request refs look like refs/pull/123/merge
+	if ref := getenv("GITHUB_REF"); strings.HasPrefix(ref, "refs/pull/") {
+		info.PullRequest = strings.Split(strings.TrimPrefix(ref, "refs/pull/"), "/")[0]
+		if repo != "" {
+			info.PullRequestURL = fmt.Sprintf("%s/pull/%s", repo, info.PullRequest)
+		}
+	}
+
+	return info
+}
+
+func detectGitLab(getenv func(string) string) Info {
+	repo := getenv("CI_PROJECT_URL")
+
+	info := Info{
+		Provider:    GitLabCI,
+		Branch:      firstOf(getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"), getenv("CI_COMMIT_REF_NAME")),
+		Commit:      getenv("CI_COMMIT_SHA"),
+		Repository:  repo,
+		JobURL:      getenv("CI_JOB_URL"),
+		PipelineID:  getenv("CI_PIPELINE_ID"),
+		PullRequest: getenv("CI_MERGE_REQUEST_IID"),
+	}
+
+	if repo != "" {
+		if info.Commit != "" {
+			info.CommitURL = fmt.Sprintf("%s/-/commit/%s", repo, info.Commit)
+		}
+		if info.PullRequest != "" {
+			info.PullRequestURL = fmt.Sprintf("%s/-/merge_requests/%s", repo, info.PullRequest)
+		}
+	}
+
+	return info
+}
+
+func detectJenkins(getenv func(string) string) Info {
+	return Info{
+		Provider:       Jenkins,
+		Branch:         firstOf(getenv("CHANGE_BRANCH"), getenv("BRANCH_NAME"), strings.TrimPrefix(getenv("GIT_BRANCH"), "origin/")),
+		Commit:         getenv("GIT_COMMIT"),
+		Repository:     getenv("GIT_URL"),
+		JobURL:         getenv("BUILD_URL"),
+		PipelineID:     firstOf(getenv("BUILD_NUMBER"), getenv("BUILD_ID")),
+		PullRequest:    getenv("CHANGE_ID"),
+		PullRequestURL: getenv("CHANGE_URL"),
+	}
+}
+
+func detectAzure(getenv func(string) string) Info {
+	info := Info{
+		Provider:    AzurePipelines,
+		Branch:      strings.TrimPrefix(firstOf(getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), getenv("BUILD_SOURCEBRANCH")), "refs/heads/"),
+		Commit:      getenv("BUILD_SOURCEVERSION"),
+		Repository:  getenv("BUILD_REPOSITORY_URI"),
+		PipelineID:  getenv("BUILD_BUILDID"),
+		PullRequest: firstOf(getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER"), getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")),
+	}
+
+	if collection, project := getenv("SYSTEM_COLLECTIONURI"), getenv("SYSTEM_TEAMPROJECT"); collection != "" && project != "" && info.PipelineID != "" {
+		info.JobURL = fmt.Sprintf("%s/%s/_build/results?buildId=%s", strings.TrimSuffix(collection, "/"), project, info.PipelineID)
+	}
+
+	if info.Repository != "" {
+		if info.Commit != "" {
+			info.CommitURL = fmt.Sprintf("%s/commit/%s", info.Repository, info.Commit)
+		}
+		if info.PullRequest != "" {
+			info.PullRequestURL = fmt.Sprintf("%s/pullrequest/%s", info.Repository, info.PullRequest)
+		}
+	}
+
+	return info
+}
+
+func detectCircle(getenv func(string) string) Info {
+	info := Info{
+		Provider:       CircleCI,
+		Branch:         getenv("CIRCLE_BRANCH"),
+		Commit:         getenv("CIRCLE_SHA1"),
+		Repository:     getenv("CIRCLE_REPOSITORY_URL"),
+		JobURL:         getenv("CIRCLE_BUILD_URL"),
+		PipelineID:     firstOf(getenv("CIRCLE_PIPELINE_ID"), getenv("CIRCLE_WORKFLOW_ID"), getenv("CIRCLE_BUILD_NUM")),
+		PullRequest:    getenv("CIRCLE_PR_NUMBER"),
+		PullRequestURL: getenv("CIRCLE_PULL_REQUEST"),
+	}
+
+	if info.PullRequest == "" && info.PullRequestURL != "" {
+		info.PullRequest = info.PullRequestURL[strings.LastIndex(info.PullRequestURL, "/")+1:]
+	}
+
+	return info
+}
+
+// firstOf returns the first non-empty value
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
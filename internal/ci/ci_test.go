@@ -0,0 +1,172 @@
+package ci
+
+import (
+	"reflect"
+	"testing"
+)
+
+func fixtureEnv(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Info
+	}{
+		{
+			name: "no ci",
+			env:  map[string]string{"HOME": "/root"},
+			want: Info{},
+		},
+		{
+			name: "github actions pull request",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "acme/shop",
+				"GITHUB_HEAD_REF":   "feature/cart",
+				"GITHUB_REF_NAME":   "42/merge",
+				"GITHUB_REF":        "refs/pull/42/merge",
+				"GITHUB_SHA":        "0123456789abcdef",
+				"GITHUB_RUN_ID":     "987",
+			},
+			want: Info{
+				Provider:       GitHubActions,
+				Branch:         "feature/cart",
+				Commit:         "0123456789abcdef",
+				CommitURL:      "https://github.com/acme/shop/commit/0123456789abcdef",
+				Repository:     "https://github.com/acme/shop",
+				JobURL:         "https://github.com/acme/shop/actions/runs/987",
+				PipelineID:     "987",
+				PullRequest:    "42",
+				PullRequestURL: "https://github.com/acme/shop/pull/42",
+			},
+		},
+		{
+			name: "github actions push",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_REPOSITORY": "acme/shop",
+				"GITHUB_REF_NAME":   "main",
+				"GITHUB_REF":        "refs/heads/main",
+				"GITHUB_SHA":        "abc",
+				"GITHUB_RUN_ID":     "1",
+			},
+			want: Info{
+				Provider:   GitHubActions,
+				Branch:     "main",
+				Commit:     "abc",
+				CommitURL:  "https://github.com/acme/shop/commit/abc",
+				Repository: "https://github.com/acme/shop",
+				JobURL:     "https://github.com/acme/shop/actions/runs/1",
+				PipelineID: "1",
+			},
+		},
+		{
+			name: "gitlab merge request",
+			env: map[string]string{
+				"GITLAB_CI":                           "true",
+				"CI_PROJECT_URL":                      "https://gitlab.com/acme/shop",
+				"CI_COMMIT_REF_NAME":                  "feature/cart",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature/cart",
+				"CI_COMMIT_SHA":                       "abc",
+				"CI_JOB_URL":                          "https://gitlab.com/acme/shop/-/jobs/5",
+				"CI_PIPELINE_ID":                      "77",
+				"CI_MERGE_REQUEST_IID":                "3",
+			},
+			want: Info{
+				Provider:       GitLabCI,
+				Branch:         "feature/cart",
+				Commit:         "abc",
+				CommitURL:      "https://gitlab.com/acme/shop/-/commit/abc",
+				Repository:     "https://gitlab.com/acme/shop",
+				JobURL:         "https://gitlab.com/acme/shop/-/jobs/5",
+				PipelineID:     "77",
+				PullRequest:    "3",
+				PullRequestURL: "https://gitlab.com/acme/shop/-/merge_requests/3",
+			},
+		},
+		{
+			name: "jenkins",
+			env: map[string]string{
+				"JENKINS_URL":  "https://ci.acme.io/",
+				"GIT_BRANCH":   "origin/develop",
+				"GIT_COMMIT":   "abc",
+				"GIT_URL":      "https://github.com/acme/shop.git",
+				"BUILD_URL":    "https://ci.acme.io/job/shop/12/",
+				"BUILD_NUMBER": "12",
+			},
+			want: Info{
+				Provider:   Jenkins,
+				Branch:     "develop",
+				Commit:     "abc",
+				Repository: "https://github.com/acme/shop.git",
+				JobURL:     "https://ci.acme.io/job/shop/12/",
+				PipelineID: "12",
+			},
+		},
+		{
+			name: "azure pipelines",
+			env: map[string]string{
+				"TF_BUILD":             "True",
+				"BUILD_SOURCEBRANCH":   "refs/heads/main",
+				"BUILD_SOURCEVERSION":  "abc",
+				"BUILD_REPOSITORY_URI": "https://dev.azure.com/acme/shop/_git/shop",
+				"BUILD_BUILDID":        "55",
+				"SYSTEM_COLLECTIONURI": "https://dev.azure.com/acme/",
+				"SYSTEM_TEAMPROJECT":   "shop",
+			},
+			want: Info{
+				Provider:   AzurePipelines,
+				Branch:     "main",
+				Commit:     "abc",
+				CommitURL:  "https://dev.azure.com/acme/shop/_git/shop/commit/abc",
+				Repository: "https://dev.azure.com/acme/shop/_git/shop",
+				JobURL:     "https://dev.azure.com/acme/shop/_build/results?buildId=55",
+				PipelineID: "55",
+			},
+		},
+		{
+			name: "circleci",
+			env: map[string]string{
+				"CIRCLECI":              "true",
+				"CIRCLE_BRANCH":         "feature/cart",
+				"CIRCLE_SHA1":           "abc",
+				"CIRCLE_REPOSITORY_URL": "git@github.com:acme/shop.git",
+				"CIRCLE_BUILD_URL":      "https://circleci.com/gh/acme/shop/9",
+				"CIRCLE_PIPELINE_ID":    "uuid",
+				"CIRCLE_PULL_REQUEST":   "https://github.com/acme/shop/pull/8",
+			},
+			want: Info{
+				Provider:       CircleCI,
+				Branch:         "feature/cart",
+				Commit:         "abc",
+				Repository:     "git@github.com:acme/shop.git",
+				JobURL:         "https://circleci.com/gh/acme/shop/9",
+				PipelineID:     "uuid",
+				PullRequest:    "8",
+				PullRequestURL: "https://github.com/acme/shop/pull/8",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(fixtureEnv(tt.env)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfo_ShortCommit(t *testing.T) {
+	if got := (Info{Commit: "0123456789abcdef"}).ShortCommit(); got != "01234567" {
+		t.Errorf("ShortCommit() = %s, want 01234567", got)
+	}
+	if got := (Info{Commit: "abc"}).ShortCommit(); got != "abc" {
+		t.Errorf("ShortCommit() = %s, want abc", got)
+	}
+}
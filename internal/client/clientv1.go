@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/qase-tms/qasectl/internal/models/plan"
 	models "github.com/qase-tms/qasectl/internal/models/result"
 	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/models/testcase"
 )
 
 const (
@@ -171,9 +173,11 @@ func (c *ClientV1) GetEnvironments(ctx context.Context, projectCode string) ([]r
 		envs := make([]run.Environment, 0, len(resp.Result.Entities))
 		for _, env := range resp.Result.Entities {
 			envs = append(envs, run.Environment{
-				Title: env.GetTitle(),
-				ID:    env.GetId(),
-				Slug:  env.GetSlug(),
+				Title:       env.GetTitle(),
+				ID:          env.GetId(),
+				Slug:        env.GetSlug(),
+				Description: env.GetDescription(),
+				Host:        env.GetHost(),
 			})
 		}
 		return envs, resp.Result.GetTotal(), nil
@@ -187,7 +191,68 @@ func (c *ClientV1) GetEnvironments(ctx context.Context, projectCode string) ([]r
 	return environments, nil
 }
 
-// GetMilestones returns milestones
+// UpdateEnvironment updates an environment
+func (c *ClientV1) UpdateEnvironment(ctx context.Context, projectCode string, id int64, u run.EnvironmentUpdate) error {
+	const op = "client.clientv1.updateenvironment"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.EnvironmentUpdate{}
+
+	if u.Title != "" {
+		m.SetTitle(u.Title)
+	}
+
+	if u.Description != "" {
+		m.SetDescription(u.Description)
+	}
+
+	if u.Slug != "" {
+		m.SetSlug(u.Slug)
+	}
+
+	if u.Host != "" {
+		m.SetHost(u.Host)
+	}
+
+	logger.Debug("updating environment", "projectCode", projectCode, "id", id, "model", m)
+
+	_, r, err := client.EnvironmentsAPI.
+		UpdateEnvironment(ctx, projectCode, int32(id)).
+		EnvironmentUpdate(m).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("updated environment", "environmentID", id)
+
+	return nil
+}
+
+// DeleteEnvironment deletes an environment
+func (c *ClientV1) DeleteEnvironment(ctx context.Context, projectCode string, id int64) error {
+	const op = "client.clientv1.deleteenvironment"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	_, r, err := client.EnvironmentsAPI.
+		DeleteEnvironment(ctx, projectCode, int32(id)).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("deleted environment", "environmentID", id)
+
+	return nil
+}
+
+// GetMilestones returns milestones whose titles contain milestoneName
 func (c *ClientV1) GetMilestones(ctx context.Context, projectCode, milestoneName string) ([]run.Milestone, error) {
 	const op = "client.clientv1.getmilestones"
 	logger := slog.With("op", op)
@@ -196,30 +261,95 @@ func (c *ClientV1) GetMilestones(ctx context.Context, projectCode, milestoneName
 
 	ctx, client := c.getApiV1Client(ctx)
 
-	resp, r, err := client.MilestonesAPI.
-		GetMilestones(ctx, projectCode).
-		Search(milestoneName).
+	milestones, err := paginate(func(offset int32) ([]run.Milestone, int32, error) {
+		req := client.MilestonesAPI.
+			GetMilestones(ctx, projectCode).
+			Limit(paginationLimit()).
+			Offset(offset)
+
+		if milestoneName != "" {
+			req = req.Search(milestoneName)
+		}
+
+		resp, r, err := req.Execute()
+		if err != nil {
+			return nil, 0, NewQaseApiError(err.Error(), extractBody(r))
+		}
+
+		mss := make([]run.Milestone, 0, len(resp.Result.Entities))
+		for _, milestone := range resp.Result.Entities {
+			mss = append(mss, convertMilestone(milestone))
+		}
+		return mss, resp.Result.GetTotal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("got milestones", "milestones", milestones)
+
+	return milestones, nil
+}
+
+// UpdateMilestone updates a milestone
+func (c *ClientV1) UpdateMilestone(ctx context.Context, projectCode string, id int64, u run.MilestoneUpdate) error {
+	const op = "client.clientv1.updatemilestone"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.MilestoneUpdate{}
+
+	if u.Title != "" {
+		m.SetTitle(u.Title)
+	}
+
+	if u.Description != "" {
+		m.SetDescription(u.Description)
+	}
+
+	if u.Status != "" {
+		m.SetStatus(u.Status)
+	}
+
+	logger.Debug("updating milestone", "projectCode", projectCode, "id", id, "model", m)
+
+	_, r, err := client.MilestonesAPI.
+		UpdateMilestone(ctx, projectCode, int32(id)).
+		MilestoneUpdate(m).
 		Execute()
 
 	if err != nil {
-		return nil, NewQaseApiError(err.Error(), extractBody(r))
+		return NewQaseApiError(err.Error(), extractBody(r))
 	}
 
-	milestones := make([]run.Milestone, 0, len(resp.Result.Entities))
-	for _, milestone := range resp.Result.Entities {
-		milestones = append(milestones, run.Milestone{
-			Title: milestone.GetTitle(),
-			ID:    milestone.GetId(),
-		})
+	logger.Info("updated milestone", "milestoneID", id)
+
+	return nil
+}
+
+// DeleteMilestone deletes a milestone
+func (c *ClientV1) DeleteMilestone(ctx context.Context, projectCode string, id int64) error {
+	const op = "client.clientv1.deletemilestone"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	_, r, err := client.MilestonesAPI.
+		DeleteMilestone(ctx, projectCode, int32(id)).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
 	}
 
-	logger.Debug("got milestones", "milestones", milestones)
+	logger.Info("deleted milestone", "milestoneID", id)
 
-	return milestones, nil
+	return nil
 }
 
 // GetPlans returns plans
-func (c *ClientV1) GetPlans(ctx context.Context, projectCode string) ([]run.Plan, error) {
+func (c *ClientV1) GetPlans(ctx context.Context, projectCode string) ([]plan.Plan, error) {
 	const op = "client.clientv1.getplans"
 	logger := slog.With("op", op)
 
@@ -227,7 +357,7 @@ func (c *ClientV1) GetPlans(ctx context.Context, projectCode string) ([]run.Plan
 
 	ctx, client := c.getApiV1Client(ctx)
 
-	plans, err := paginate(func(offset int32) ([]run.Plan, int32, error) {
+	plans, err := paginate(func(offset int32) ([]plan.Plan, int32, error) {
 		resp, r, err := client.PlansAPI.
 			GetPlans(ctx, projectCode).
 			Limit(paginationLimit()).
@@ -237,12 +367,9 @@ func (c *ClientV1) GetPlans(ctx context.Context, projectCode string) ([]run.Plan
 			return nil, 0, NewQaseApiError(err.Error(), extractBody(r))
 		}
 
-		p := make([]run.Plan, 0, len(resp.Result.Entities))
-		for _, plan := range resp.Result.Entities {
-			p = append(p, run.Plan{
-				Title: plan.GetTitle(),
-				ID:    plan.GetId(),
-			})
+		p := make([]plan.Plan, 0, len(resp.Result.Entities))
+		for _, testPlan := range resp.Result.Entities {
+			p = append(p, convertPlan(testPlan))
 		}
 		return p, resp.Result.GetTotal(), nil
 	})
@@ -277,14 +404,190 @@ func (c *ClientV1) GetPlan(ctx context.Context, projectCode string, planID int64
 	}
 
 	return plan.PlanDetailed{
-		ID:    resp.Result.GetId(),
-		Title: resp.Result.GetTitle(),
-		Cases: cases,
+		ID:          resp.Result.GetId(),
+		Title:       resp.Result.GetTitle(),
+		Description: resp.Result.GetDescription(),
+		Cases:       cases,
 	}, nil
 }
 
+// CreatePlan creates a new test plan with the cases
+func (c *ClientV1) CreatePlan(ctx context.Context, projectCode, title, description string, cases []int64) (int64, error) {
+	const op = "client.clientv1.createplan"
+	logger := slog.With("op", op)
+
+	logger.Debug("creating plan", "projectCode", projectCode, "title", title, "description", description, "cases", cases)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.NewPlanCreate(title, cases)
+
+	if description != "" {
+		m.SetDescription(description)
+	}
+
+	resp, r, err := client.PlansAPI.
+		CreatePlan(ctx, projectCode).
+		PlanCreate(*m).
+		Execute()
+
+	if err != nil {
+		return 0, NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("created plan", "planID", resp.Result.GetId())
+
+	return resp.Result.GetId(), nil
+}
+
+// UpdatePlan updates a test plan
+func (c *ClientV1) UpdatePlan(ctx context.Context, projectCode string, id int64, u plan.Update) error {
+	const op = "client.clientv1.updateplan"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.NewPlanUpdate()
+
+	if u.Title != "" {
+		m.SetTitle(u.Title)
+	}
+
+	if u.Description != "" {
+		m.SetDescription(u.Description)
+	}
+
+	if len(u.Cases) > 0 {
+		m.SetCases(u.Cases)
+	}
+
+	logger.Debug("updating plan", "projectCode", projectCode, "id", id, "model", m)
+
+	_, r, err := client.PlansAPI.
+		UpdatePlan(ctx, projectCode, int32(id)).
+		PlanUpdate(*m).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("updated plan", "planID", id)
+
+	return nil
+}
+
+// DeletePlan deletes a test plan
+func (c *ClientV1) DeletePlan(ctx context.Context, projectCode string, id int64) error {
+	const op = "client.clientv1.deleteplan"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	_, r, err := client.PlansAPI.
+		DeletePlan(ctx, projectCode, int32(id)).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("deleted plan", "planID", id)
+
+	return nil
+}
+
+// GetCases returns the test cases matching the filter
+func (c *ClientV1) GetCases(ctx context.Context, projectCode string, f testcase.Filter) ([]testcase.TestCase, error) {
+	const op = "client.clientv1.getcases"
+	logger := slog.With("op", op)
+
+	logger.Debug("getting test cases", "projectCode", projectCode, "filter", f)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	cases, err := paginate(func(offset int32) ([]testcase.TestCase, int32, error) {
+		req := client.CasesAPI.
+			GetCases(ctx, projectCode).
+			Limit(paginationLimit()).
+			Offset(offset)
+
+		if f.Search != "" {
+			req = req.Search(f.Search)
+		}
+
+		if f.Severity != "" {
+			req = req.Severity(f.Severity)
+		}
+
+		if f.Priority != "" {
+			req = req.Priority(f.Priority)
+		}
+
+		if f.Automation != "" {
+			req = req.Automation(f.Automation)
+		}
+
+		resp, r, err := req.Execute()
+		if err != nil {
+			return nil, 0, NewQaseApiError(err.Error(), extractBody(r))
+		}
+
+		cases := make([]testcase.TestCase, 0, len(resp.Result.Entities))
+		for _, tc := range resp.Result.Entities {
+			cases = append(cases, convertTestCase(tc))
+		}
+		return cases, resp.Result.GetFiltered(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("got test cases", "count", len(cases))
+
+	return cases, nil
+}
+
+// GetSuites returns the test suites of the project
+func (c *ClientV1) GetSuites(ctx context.Context, projectCode string) ([]testcase.Suite, error) {
+	const op = "client.clientv1.getsuites"
+	logger := slog.With("op", op)
+
+	logger.Debug("getting suites", "projectCode", projectCode)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	suites, err := paginate(func(offset int32) ([]testcase.Suite, int32, error) {
+		resp, r, err := client.SuitesAPI.
+			GetSuites(ctx, projectCode).
+			Limit(paginationLimit()).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, 0, NewQaseApiError(err.Error(), extractBody(r))
+		}
+
+		suites := make([]testcase.Suite, 0, len(resp.Result.Entities))
+		for _, suite := range resp.Result.Entities {
+			suites = append(suites, testcase.Suite{
+				ID:       suite.GetId(),
+				Title:    suite.GetTitle(),
+				ParentID: suite.GetParentId(),
+			})
+		}
+		return suites, resp.Result.GetTotal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("got suites", "suites", suites)
+
+	return suites, nil
+}
+
 // CreateRun creates a new run
-func (c *ClientV1) CreateRun(ctx context.Context, projectCode, title string, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error) {
+func (c *ClientV1) CreateRun(ctx context.Context, projectCode, title string, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error) {
 	const op = "client.clientv1.createrun"
 	logger := slog.With("op", op)
 
@@ -328,6 +631,14 @@ func (c *ClientV1) CreateRun(ctx context.Context, projectCode, title string, des
 		m.SetCloudRunConfig(cloudConfig)
 	}
 
+	if len(customFields) > 0 {
+		m.SetCustomField(customFields)
+	}
+
+	if len(configurations) > 0 {
+		m.SetConfigurations(configurations)
+	}
+
 	if startTime != nil {
 		// Convert milliseconds to time.Time and format as "YYYY-MM-DD HH:MM:SS" in UTC
 		startTimeSeconds := *startTime / 1000
@@ -405,12 +716,12 @@ func (c *ClientV1) UploadData(ctx context.Context, project string, runID int64,
 	return nil
 }
 
-// GetTestRuns returns test runs
-func (c *ClientV1) GetTestRuns(ctx context.Context, projectCode string, start, end int64) ([]run.Run, error) {
-	const op = "client.clientv1.gettestruns"
+// FindRuns returns test runs matching the filter
+func (c *ClientV1) FindRuns(ctx context.Context, projectCode string, f run.Filter) ([]run.Run, error) {
+	const op = "client.clientv1.findruns"
 	logger := slog.With("op", op)
 
-	logger.Debug("getting test runs", "projectCode", projectCode)
+	logger.Debug("finding test runs", "projectCode", projectCode, "filter", f)
 
 	ctx, client := c.getApiV1Client(ctx)
 
@@ -420,12 +731,28 @@ func (c *ClientV1) GetTestRuns(ctx context.Context, projectCode string, start, e
 			Limit(paginationLimit()).
 			Offset(offset)
 
-		if start != 0 {
-			req = req.FromStartTime(start)
+		if f.Search != "" {
+			req = req.Search(f.Search)
+		}
+
+		if f.Status != "" {
+			req = req.Status(f.Status)
+		}
+
+		if f.FromStartTime != 0 {
+			req = req.FromStartTime(f.FromStartTime)
 		}
 
-		if end != 0 {
-			req = req.ToStartTime(end)
+		if f.ToStartTime != 0 {
+			req = req.ToStartTime(f.ToStartTime)
+		}
+
+		if f.MilestoneID != 0 {
+			req = req.Milestone(int32(f.MilestoneID))
+		}
+
+		if f.EnvironmentID != 0 {
+			req = req.Environment(int32(f.EnvironmentID))
 		}
 
 		resp, r, err := req.Execute()
@@ -435,9 +762,7 @@ func (c *ClientV1) GetTestRuns(ctx context.Context, projectCode string, start, e
 
 		runs := make([]run.Run, 0, len(resp.Result.Entities))
 		for _, testRun := range resp.Result.Entities {
-			runs = append(runs, run.Run{
-				ID: testRun.GetId(),
-			})
+			runs = append(runs, convertRun(testRun))
 		}
 		return runs, resp.Result.GetFiltered(), nil
 	})
@@ -445,11 +770,211 @@ func (c *ClientV1) GetTestRuns(ctx context.Context, projectCode string, start, e
 		return nil, err
 	}
 
-	logger.Debug("got test runs", "testRuns", testRuns)
+	logger.Debug("found test runs", "testRuns", testRuns)
 
 	return testRuns, nil
 }
 
+// GetRunResults returns the results of a test run
+func (c *ClientV1) GetRunResults(ctx context.Context, projectCode string, runID int64) ([]run.Result, error) {
+	const op = "client.clientv1.getrunresults"
+	logger := slog.With("op", op)
+
+	logger.Debug("getting test run results", "projectCode", projectCode, "runID", runID)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	results, err := paginate(func(offset int32) ([]run.Result, int32, error) {
+		resp, r, err := client.ResultsAPI.
+			GetResults(ctx, projectCode).
+			Run(strconv.FormatInt(runID, 10)).
+			Limit(paginationLimit()).
+			Offset(offset).
+			Execute()
+		if err != nil {
+			return nil, 0, NewQaseApiError(err.Error(), extractBody(r))
+		}
+
+		results := make([]run.Result, 0, len(resp.Result.Entities))
+		for _, result := range resp.Result.Entities {
+			results = append(results, convertRunResult(result))
+		}
+		return results, resp.Result.GetFiltered(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("got test run results", "count", len(results))
+
+	return results, nil
+}
+
+// GetRun returns a test run
+func (c *ClientV1) GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	return c.getRun(ctx, projectCode, id, false)
+}
+
+// GetRunWithCases returns a test run with the IDs of its cases
+func (c *ClientV1) GetRunWithCases(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	return c.getRun(ctx, projectCode, id, true)
+}
+
+// getRun returns a test run. The cases are only requested when needed, as large runs hold thousands of them.
+func (c *ClientV1) getRun(ctx context.Context, projectCode string, id int64, withCases bool) (run.Run, error) {
+	const op = "client.clientv1.getrun"
+	logger := slog.With("op", op)
+
+	logger.Debug("getting test run", "projectCode", projectCode, "id", id, "withCases", withCases)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	req := client.RunsAPI.GetRun(ctx, projectCode, int32(id))
+	if withCases {
+		req = req.Include("cases")
+	}
+
+	resp, r, err := req.Execute()
+
+	if err != nil {
+		return run.Run{}, NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	testRun := convertRun(*resp.Result)
+
+	logger.Debug("got test run", "testRun", testRun)
+
+	return testRun, nil
+}
+
+// CreateRunFrom creates a test run with the cases, plan and configuration of the given run
+func (c *ClientV1) CreateRunFrom(ctx context.Context, projectCode string, src run.Run) (int64, error) {
+	const op = "client.clientv1.createrunfrom"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.RunCreate{
+		Title: src.Title,
+	}
+
+	if src.Description != "" {
+		m.SetDescription(src.Description)
+	}
+
+	if src.EnvironmentID != 0 {
+		m.SetEnvironmentId(src.EnvironmentID)
+	}
+
+	if src.MilestoneID != 0 {
+		m.SetMilestoneId(src.MilestoneID)
+	}
+
+	if src.PlanID != 0 {
+		m.SetPlanId(src.PlanID)
+	}
+
+	if len(src.Cases) > 0 {
+		m.SetCases(src.Cases)
+	}
+
+	if len(src.Configurations) > 0 {
+		m.SetConfigurations(src.Configurations)
+	}
+
+	if len(src.Tags) > 0 {
+		m.SetTags(src.Tags)
+	}
+
+	if len(src.CustomFields) > 0 {
+		m.SetCustomField(src.CustomFields)
+	}
+
+	logger.Debug("creating run", "projectCode", projectCode, "model", m)
+
+	resp, r, err := client.RunsAPI.
+		CreateRun(ctx, projectCode).
+		RunCreate(m).
+		Execute()
+
+	if err != nil {
+		return 0, NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("created run", "runID", resp.Result.GetId(), "title", src.Title)
+
+	return resp.Result.GetId(), nil
+}
+
+// UpdateRun updates a test run
+func (c *ClientV1) UpdateRun(ctx context.Context, projectCode string, id int64, u run.Update) error {
+	const op = "client.clientv1.updaterun"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.RunUpdate{}
+
+	if u.Title != "" {
+		m.SetTitle(u.Title)
+	}
+
+	if u.Description != "" {
+		m.SetDescription(u.Description)
+	}
+
+	if u.EnvironmentSlug != "" {
+		m.SetEnvironmentSlug(u.EnvironmentSlug)
+	}
+
+	if u.MilestoneID != 0 {
+		m.SetMilestoneId(u.MilestoneID)
+	}
+
+	if len(u.Tags) > 0 {
+		m.SetTags(u.Tags)
+	}
+
+	if len(u.CustomFields) > 0 {
+		m.SetCustomField(u.CustomFields)
+	}
+
+	logger.Debug("updating run", "projectCode", projectCode, "id", id, "model", m)
+
+	_, r, err := client.RunsAPI.
+		UpdateRun(ctx, projectCode, int32(id)).
+		RunUpdate(m).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("updated run", "runID", id)
+
+	return nil
+}
+
+// AbortRun aborts a test run
+func (c *ClientV1) AbortRun(ctx context.Context, projectCode string, id int64) error {
+	const op = "client.clientv1.abortrun"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	_, r, err := client.RunsAPI.
+		AbortRun(ctx, projectCode, int32(id)).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("aborted run", "runID", id)
+
+	return nil
+}
+
 // DeleteTestRun deletes test run
 func (c *ClientV1) DeleteTestRun(ctx context.Context, projectCode string, id int64) error {
 	const op = "client.clientv1.deletetestrun"
@@ -491,10 +1016,7 @@ func (c *ClientV1) GetCustomFields(ctx context.Context) ([]custom.CustomField, e
 
 		fields := make([]custom.CustomField, 0, len(resp.Result.Entities))
 		for _, field := range resp.Result.Entities {
-			fields = append(fields, custom.CustomField{
-				ID:    field.GetId(),
-				Title: field.GetTitle(),
-			})
+			fields = append(fields, convertCustomField(field))
 		}
 		return fields, resp.Result.GetTotal(), nil
 	})
@@ -507,6 +1029,132 @@ func (c *ClientV1) GetCustomFields(ctx context.Context) ([]custom.CustomField, e
 	return customFields, nil
 }
 
+// CreateCustomField creates a custom field and returns its ID
+func (c *ClientV1) CreateCustomField(ctx context.Context, f custom.CustomField) (int64, error) {
+	const op = "client.clientv1.createcustomfield"
+	logger := slog.With("op", op)
+
+	logger.Debug("creating custom field", "field", f)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.CustomFieldCreate{
+		Title:  f.Title,
+		Entity: int32(slices.Index(custom.Entities, f.Entity)),
+		Type:   int32(slices.Index(custom.Types, f.Type)),
+	}
+
+	if len(f.Options) > 0 {
+		m.SetValue(convertCustomFieldOptions(f))
+	}
+
+	if f.Placeholder != "" {
+		m.SetPlaceholder(f.Placeholder)
+	}
+
+	if f.DefaultValue != "" {
+		m.SetDefaultValue(f.DefaultValue)
+	}
+
+	m.SetIsRequired(f.Required)
+	m.SetIsVisible(!f.Hidden)
+	m.SetIsFilterable(f.Filterable)
+	m.SetIsEnabledForAllProjects(f.AllProjects)
+
+	if len(f.Projects) > 0 {
+		m.SetProjectsCodes(f.Projects)
+	}
+
+	resp, r, err := client.CustomFieldsAPI.
+		CreateCustomField(ctx).
+		CustomFieldCreate(m).
+		Execute()
+
+	if err != nil {
+		return 0, NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("created custom field", "fieldID", resp.Result.GetId(), "title", f.Title)
+
+	return resp.Result.GetId(), nil
+}
+
+// UpdateCustomField updates a custom field. The entity and the type of a custom field cannot be changed.
+func (c *ClientV1) UpdateCustomField(ctx context.Context, id int64, f custom.CustomField) error {
+	const op = "client.clientv1.updatecustomfield"
+	logger := slog.With("op", op)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	m := apiV1Client.CustomFieldUpdate{
+		Title: f.Title,
+	}
+
+	if len(f.Options) > 0 {
+		m.SetValue(convertCustomFieldOptions(f))
+	}
+
+	m.SetPlaceholder(f.Placeholder)
+	m.SetDefaultValue(f.DefaultValue)
+	m.SetIsRequired(f.Required)
+	m.SetIsVisible(!f.Hidden)
+	m.SetIsFilterable(f.Filterable)
+	m.SetIsEnabledForAllProjects(f.AllProjects)
+	m.SetProjectsCodes(f.Projects)
+
+	logger.Debug("updating custom field", "id", id, "model", m)
+
+	_, r, err := client.CustomFieldsAPI.
+		UpdateCustomField(ctx, int32(id)).
+		CustomFieldUpdate(m).
+		Execute()
+
+	if err != nil {
+		return NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	logger.Info("updated custom field", "fieldID", id)
+
+	return nil
+}
+
+// GetConfigurations returns configuration groups of the project
+func (c *ClientV1) GetConfigurations(ctx context.Context, projectCode string) ([]run.ConfigurationGroup, error) {
+	const op = "client.clientv1.getconfigurations"
+	logger := slog.With("op", op)
+
+	logger.Debug("getting configurations", "projectCode", projectCode)
+
+	ctx, client := c.getApiV1Client(ctx)
+
+	resp, r, err := client.ConfigurationsAPI.
+		GetConfigurations(ctx, projectCode).
+		Execute()
+
+	if err != nil {
+		return nil, NewQaseApiError(err.Error(), extractBody(r))
+	}
+
+	groups := make([]run.ConfigurationGroup, 0, len(resp.Result.Entities))
+	for _, group := range resp.Result.Entities {
+		g := run.ConfigurationGroup{
+			ID:    group.GetId(),
+			Title: group.GetTitle(),
+		}
+		for _, conf := range group.GetConfigurations() {
+			g.Configurations = append(g.Configurations, run.Configuration{
+				ID:    conf.GetId(),
+				Title: conf.GetTitle(),
+			})
+		}
+		groups = append(groups, g)
+	}
+
+	logger.Debug("got configurations", "groups", groups)
+
+	return groups, nil
+}
+
 // RemoveCustomFieldByID removes a custom field by ID
 func (c *ClientV1) RemoveCustomFieldByID(ctx context.Context, fieldID int32) error {
 	const op = "client.clientv1.removecustomfield"
@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -171,3 +172,24 @@ func makeStrings(n int) []string {
 	}
 	return s
 }
+
+func TestParseCustomFieldOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []customFieldOption
+	}{
+		{name: "empty", value: "", want: nil},
+		{name: "list", value: `[{"id":2,"title":"API"},{"id":1,"title":"E2E"}]`, want: []customFieldOption{{ID: 2, Title: "API"}, {ID: 1, Title: "E2E"}}},
+		{name: "map", value: `{"2":"API","1":"E2E"}`, want: []customFieldOption{{ID: 1, Title: "E2E"}, {ID: 2, Title: "API"}}},
+		{name: "plain value", value: "1.2.0", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCustomFieldOptions(tt.value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCustomFieldOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,22 @@
 package client
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"fmt"
 	apiV1Client "github.com/qase-tms/qase-go/qase-api-client"
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+	"github.com/qase-tms/qasectl/internal/models/plan"
 	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/models/testcase"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 func (c *ClientV1) convertResultToApiModel(ctx context.Context, projectCode string, result models.Result) apiV1Client.ResultCreate {
@@ -92,15 +102,10 @@ func (c *ClientV1) convertAttachments(ctx context.Context, projectCode string, a
 	for _, attachment := range attachments {
 		rmAttach := false
 		if attachment.FilePath == nil {
-			path, err := os.Getwd()
-			if err != nil {
-				logger.Warn("cannot get executable path", "error", err)
-			}
-
-			fp := filepath.Join(path, attachment.Name)
-			err = os.WriteFile(fp, *attachment.Content, 0644)
+			fp, err := writeTempAttachment(attachment)
 			if err != nil {
 				logger.Warn("cannot write file", "error", err)
+				continue
 			}
 
 			attachment.FilePath = &fp
@@ -137,10 +142,37 @@ func (c *ClientV1) convertAttachments(ctx context.Context, projectCode string, a
 	return results
 }
 
+// writeTempAttachment writes the in-memory content of the attachment to a new temporary directory,
+// so the uploaded file keeps the attachment name and no file of the working directory is overwritten
+func writeTempAttachment(attachment models.Attachment) (string, error) {
+	if attachment.Content == nil {
+		return "", fmt.Errorf("attachment %s has neither a file nor content", attachment.Name)
+	}
+
+	dir, err := os.MkdirTemp("", "qasectl-attachment-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	name := filepath.Base(attachment.Name)
+	if name == "." || name == string(filepath.Separator) {
+		name = "attachment"
+	}
+
+	fp := filepath.Join(dir, name)
+	if err := os.WriteFile(fp, *attachment.Content, 0644); err != nil {
+		removeFile(fp)
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	return fp, nil
+}
+
+// removeFile removes the temporary attachment file with its directory
 func removeFile(path string) {
 	const op = "client.converterv1.removefile"
 	logger := slog.With("op", op)
-	err := os.Remove(path)
+	err := os.RemoveAll(filepath.Dir(path))
 	if err != nil {
 		logger.Warn("cannot remove file", "error", err)
 	}
@@ -182,3 +214,200 @@ func (c *ClientV1) convertStepToMaps(ctx context.Context, projectCode string, st
 
 	return stepModels
 }
+
+// convertRun converts an API test run to the run model
+func convertRun(r apiV1Client.Run) run.Run {
+	testRun := run.Run{
+		ID:          r.GetId(),
+		Title:       r.GetTitle(),
+		Description: r.GetDescription(),
+		Status:      r.GetStatusText(),
+		PlanID:      r.GetPlanId(),
+	}
+
+	if startTime, ok := r.GetStartTimeOk(); ok {
+		testRun.StartTime = startTime
+	}
+
+	if endTime, ok := r.GetEndTimeOk(); ok {
+		testRun.EndTime = endTime
+	}
+
+	if env, ok := r.GetEnvironmentOk(); ok && env != nil {
+		testRun.Environment = env.GetSlug()
+		testRun.EnvironmentID = env.GetId()
+	}
+
+	if milestone, ok := r.GetMilestoneOk(); ok && milestone != nil {
+		testRun.Milestone = milestone.GetTitle()
+		testRun.MilestoneID = milestone.GetId()
+	}
+
+	for _, tag := range r.GetTags() {
+		testRun.Tags = append(testRun.Tags, tag.GetTitle())
+	}
+
+	testRun.Cases = r.GetCases()
+	testRun.Configurations = r.GetConfigurations()
+
+	for _, field := range r.GetCustomFields() {
+		if testRun.CustomFields == nil {
+			testRun.CustomFields = make(map[string]string)
+		}
+		testRun.CustomFields[strconv.FormatInt(field.GetId(), 10)] = field.GetValue()
+	}
+
+	stats := r.GetStats()
+	testRun.Stats = run.Stats{
+		Total:      int(stats.GetTotal()),
+		Untested:   int(stats.GetUntested()),
+		Passed:     int(stats.GetPassed()),
+		Failed:     int(stats.GetFailed()),
+		Blocked:    int(stats.GetBlocked()),
+		Skipped:    int(stats.GetSkipped()),
+		Retest:     int(stats.GetRetest()),
+		InProgress: int(stats.GetInProgress()),
+		Invalid:    int(stats.GetInvalid()),
+	}
+
+	return testRun
+}
+
+// convertRunResult converts an API test result to the run result model
+func convertRunResult(r apiV1Client.Result) run.Result {
+	result := run.Result{
+		CaseID: r.GetCaseId(),
+		Status: r.GetStatus(),
+	}
+
+	if endTime, ok := r.GetEndTimeOk(); ok {
+		result.EndTime = endTime
+	}
+
+	return result
+}
+
+// convertTestCase converts an API test case to the test case model
+func convertTestCase(tc apiV1Client.TestCase) testcase.TestCase {
+	testCase := testcase.TestCase{
+		ID:      tc.GetId(),
+		Title:   tc.GetTitle(),
+		SuiteID: tc.GetSuiteId(),
+	}
+
+	for _, tag := range tc.GetTags() {
+		testCase.Tags = append(testCase.Tags, tag.GetTitle())
+	}
+
+	return testCase
+}
+
+// convertPlan converts an API test plan to the plan model
+func convertPlan(p apiV1Client.Plan) plan.Plan {
+	testPlan := plan.Plan{
+		ID:          p.GetId(),
+		Title:       p.GetTitle(),
+		Description: p.GetDescription(),
+		CasesCount:  int(p.GetCasesCount()),
+	}
+
+	if createdAt, ok := p.GetCreatedAtOk(); ok {
+		testPlan.CreatedAt = createdAt
+	}
+
+	return testPlan
+}
+
+// convertMilestone converts an API milestone to the milestone model
+func convertMilestone(m apiV1Client.Milestone) run.Milestone {
+	milestone := run.Milestone{
+		Title:       m.GetTitle(),
+		ID:          m.GetId(),
+		Description: m.GetDescription(),
+		Status:      m.GetStatus(),
+	}
+
+	if dueDate, ok := m.GetDueDateOk(); ok {
+		milestone.DueDate = dueDate
+	}
+
+	return milestone
+}
+
+// convertCustomField converts an API custom field to the custom field model
+func convertCustomField(f apiV1Client.CustomField) custom.CustomField {
+	field := custom.CustomField{
+		ID:           f.GetId(),
+		Title:        f.GetTitle(),
+		Entity:       strings.ToLower(f.GetEntity()),
+		Type:         strings.ToLower(f.GetType()),
+		Placeholder:  f.GetPlaceholder(),
+		DefaultValue: f.GetDefaultValue(),
+		Required:     f.GetIsRequired(),
+		Hidden:       !f.GetIsVisible(),
+		Filterable:   f.GetIsFilterable(),
+		AllProjects:  f.GetIsEnabledForAllProjects(),
+		Projects:     f.GetProjectsCodes(),
+	}
+
+	for _, option := range parseCustomFieldOptions(f.GetValue()) {
+		if field.OptionIDs == nil {
+			field.OptionIDs = make(map[string]int64)
+		}
+		field.Options = append(field.Options, option.Title)
+		field.OptionIDs[option.Title] = option.ID
+	}
+
+	return field
+}
+
+// customFieldOption is an option of a custom field
+type customFieldOption struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// parseCustomFieldOptions parses the JSON encoded options of a custom field sorted by ID.
+// The options are encoded either as a list of objects or as a map of IDs to titles.
+func parseCustomFieldOptions(value string) []customFieldOption {
+	if value == "" {
+		return nil
+	}
+
+	var options []customFieldOption
+	if err := json.Unmarshal([]byte(value), &options); err == nil {
+		return options
+	}
+
+	var titles map[string]string
+	if err := json.Unmarshal([]byte(value), &titles); err != nil {
+		return nil
+	}
+
+	for id, title := range titles {
+		optionID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		options = append(options, customFieldOption{ID: optionID, Title: title})
+	}
+	slices.SortFunc(options, func(a, b customFieldOption) int { return cmp.Compare(a.ID, b.ID) })
+
+	return options
+}
+
+// convertCustomFieldOptions converts the options of a custom field to the API model.
+// The existing options keep their IDs so that the values set in the test cases are preserved.
+func convertCustomFieldOptions(f custom.CustomField) []apiV1Client.CustomFieldCreateValueInner {
+	values := make([]apiV1Client.CustomFieldCreateValueInner, 0, len(f.Options))
+	for _, option := range f.Options {
+		v := apiV1Client.CustomFieldCreateValueInner{}
+		v.SetTitle(option)
+		if id, ok := f.OptionIDs[option]; ok {
+			v.SetId(id)
+		}
+		values = append(values, v)
+	}
+
+	return values
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+func TestWriteTempAttachment(t *testing.T) {
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	existing := filepath.Join(cwd, "build.log")
+	if err := os.WriteFile(existing, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		wantName string
+	}{
+		{name: "keeps the name", file: "build.log", wantName: "build.log"},
+		{name: "drops directories", file: "../logs/build.log", wantName: "build.log"},
+		{name: "empty name", file: "", wantName: "attachment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte("redacted")
+			fp, err := writeTempAttachment(models.Attachment{Name: tt.file, Content: &content})
+			if err != nil {
+				t.Fatalf("writeTempAttachment() error = %v", err)
+			}
+			defer removeFile(fp)
+
+			if filepath.Base(fp) != tt.wantName || filepath.Dir(fp) == cwd {
+				t.Errorf("writeTempAttachment() = %v, want a temporary %v", fp, tt.wantName)
+			}
+			if data, _ := os.ReadFile(fp); string(data) != "redacted" {
+				t.Errorf("content = %s, want redacted", data)
+			}
+		})
+	}
+
+	// two attachments with the same name don't share a file
+	content := []byte("redacted")
+	first, _ := writeTempAttachment(models.Attachment{Name: "build.log", Content: &content})
+	second, _ := writeTempAttachment(models.Attachment{Name: "build.log", Content: &content})
+	if first == second {
+		t.Errorf("writeTempAttachment() = %v twice, want unique files", first)
+	}
+	removeFile(first)
+	removeFile(second)
+
+	if data, _ := os.ReadFile(existing); string(data) != "original" {
+		t.Errorf("working directory file = %s, want it untouched", data)
+	}
+	if _, err := os.Stat(filepath.Dir(first)); !os.IsNotExist(err) {
+		t.Errorf("temporary directory %s is not removed", filepath.Dir(first))
+	}
+}
+
+func TestWriteTempAttachment_NoContent(t *testing.T) {
+	if _, err := writeTempAttachment(models.Attachment{Name: "build.log"}); err == nil {
+		t.Error("writeTempAttachment() error = nil, want an error")
+	}
+}
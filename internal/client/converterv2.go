@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"log/slog"
+
 	apiV2Client "github.com/qase-tms/qase-go/qase-api-v2-client"
 	models "github.com/qase-tms/qasectl/internal/models/result"
 )
@@ -144,7 +146,12 @@ func (c *ClientV2) createStepModel(ctx context.Context, projectCode string, step
 }
 
 func (c *ClientV2) createStepExecution(ctx context.Context, projectCode string, execution models.StepExecution) apiV2Client.ResultStepExecution {
-	status, _ := apiV2Client.NewResultStepStatusFromValue(execution.Status)
+	status, err := apiV2Client.NewResultStepStatusFromValue(execution.Status)
+	if err != nil {
+		slog.Warn("unknown step status, using blocked", "status", execution.Status)
+		blocked := apiV2Client.ResultStepStatus("blocked")
+		status = &blocked
+	}
 	exec := apiV2Client.NewResultStepExecution(*status)
 
 	exec.Attachments = c.clientV1.convertAttachments(ctx, projectCode, execution.Attachments)
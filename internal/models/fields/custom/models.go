@@ -1,7 +1,34 @@
 package custom
 
+// Entities are the entities custom fields belong to. The index of an entity is its ID in the API.
+var Entities = []string{"case", "run", "defect"}
+
+// Types are the types of custom fields. The index of a type is its ID in the API.
+var Types = []string{"number", "string", "text", "selectbox", "checkbox", "radio", "multiselect", "url", "user", "datetime"}
+
+// OptionTypes are the types of custom fields with options
+var OptionTypes = []string{"selectbox", "checkbox", "radio", "multiselect"}
+
 // Model for custom fields
 type CustomField struct {
-	ID    int64  `json:"id"`
-	Title string `json:"title"`
+	ID           int64    `json:"id,omitempty" yaml:"id,omitempty"`
+	Title        string   `json:"title" yaml:"title"`
+	Entity       string   `json:"entity,omitempty" yaml:"entity,omitempty"`
+	Type         string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Options      []string `json:"options,omitempty" yaml:"options,omitempty"`
+	Placeholder  string   `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	DefaultValue string   `json:"default_value,omitempty" yaml:"default_value,omitempty"`
+	Required     bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	Filterable   bool     `json:"filterable,omitempty" yaml:"filterable,omitempty"`
+	// AllProjects enables the field in all projects, otherwise only in Projects
+	AllProjects bool     `json:"all_projects,omitempty" yaml:"all_projects,omitempty"`
+	Projects    []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+	// OptionIDs maps the titles of the existing options to their IDs
+	OptionIDs map[string]int64 `json:"-" yaml:"-"`
+}
+
+// Spec describes the custom fields of a workspace
+type Spec struct {
+	Fields []CustomField `json:"fields" yaml:"fields"`
 }
@@ -1,7 +1,31 @@
 package plan
 
+import "time"
+
+// Plan is a test plan of a project
+type Plan struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	CasesCount  int        `json:"cases_count"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
 type PlanDetailed struct {
-	ID    int64
-	Title string
-	Cases []int64
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	Cases       []int64 `json:"cases"`
+}
+
+// Update holds the changes of a plan. Empty values are left unchanged.
+type Update struct {
+	Title       string
+	Description string
+	Cases       []int64
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u Update) IsEmpty() bool {
+	return u.Title == "" && u.Description == "" && len(u.Cases) == 0
 }
@@ -1,21 +1,162 @@
 package run
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 type Environment struct {
-	Title string `json:"title"`
-	ID    int64  `json:"id"`
-	Slug  string `json:"slug"`
+	Title       string `json:"title"`
+	ID          int64  `json:"id"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Host        string `json:"host,omitempty"`
+}
+
+// EnvironmentUpdate holds the changes of an environment. Empty values are left unchanged.
+type EnvironmentUpdate struct {
+	Title       string
+	Description string
+	Slug        string
+	Host        string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u EnvironmentUpdate) IsEmpty() bool {
+	return u.Title == "" && u.Description == "" && u.Slug == "" && u.Host == ""
 }
 
 type Milestone struct {
-	Title string `json:"title"`
-	ID    int64  `json:"id"`
+	Title       string     `json:"title"`
+	ID          int64      `json:"id"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
 }
 
-type Plan struct {
-	Title string `json:"title"`
+// MilestoneUpdate holds the changes of a milestone. Empty values are left unchanged.
+type MilestoneUpdate struct {
+	Title       string
+	Description string
+	Status      string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u MilestoneUpdate) IsEmpty() bool {
+	return u.Title == "" && u.Description == "" && u.Status == ""
+}
+
+// ConfigurationGroup holds configurations like OS or browser, e.g. Linux and Windows
+type ConfigurationGroup struct {
+	ID             int64           `json:"id"`
+	Title          string          `json:"title"`
+	Configurations []Configuration `json:"configurations"`
+}
+
+type Configuration struct {
 	ID    int64  `json:"id"`
+	Title string `json:"title"`
 }
 
 type Run struct {
-	ID int64 `json:"id"`
+	ID             int64             `json:"id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description,omitempty"`
+	Status         string            `json:"status"`
+	Environment    string            `json:"environment,omitempty"`
+	EnvironmentID  int64             `json:"environment_id,omitempty"`
+	Milestone      string            `json:"milestone,omitempty"`
+	MilestoneID    int64             `json:"milestone_id,omitempty"`
+	PlanID         int64             `json:"plan_id,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Cases          []int64           `json:"cases,omitempty"`
+	Configurations []int64           `json:"configurations,omitempty"`
+	CustomFields   map[string]string `json:"custom_fields,omitempty"`
+	StartTime      *time.Time        `json:"start_time,omitempty"`
+	EndTime        *time.Time        `json:"end_time,omitempty"`
+	Stats          Stats             `json:"stats"`
+}
+
+// Update holds the changes of a run. Empty values are left unchanged.
+type Update struct {
+	Title           string
+	Description     string
+	EnvironmentSlug string
+	MilestoneID     int64
+	Tags            []string
+	CustomFields    map[string]string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u Update) IsEmpty() bool {
+	return u.Title == "" && u.Description == "" && u.EnvironmentSlug == "" && u.MilestoneID == 0 &&
+		len(u.Tags) == 0 && len(u.CustomFields) == 0
+}
+
+// Result is the result of a test case in a run
+type Result struct {
+	CaseID  int64      `json:"case_id"`
+	Status  string     `json:"status"`
+	EndTime *time.Time `json:"end_time,omitempty"`
+}
+
+// DefaultSelectStatuses are the result statuses of the cases selected from a test run by default
+var DefaultSelectStatuses = []string{"failed", "invalid", "blocked"}
+
+// SelectCases returns the sorted IDs of the cases whose last result has one of the statuses.
+// Cases that passed on a retry are not selected.
+func SelectCases(results []Result, statuses []string) []int64 {
+	last := make(map[int64]Result, len(results))
+	for _, r := range results {
+		if r.CaseID == 0 {
+			continue
+		}
+		prev, ok := last[r.CaseID]
+		if ok && prev.EndTime != nil && (r.EndTime == nil || r.EndTime.Before(*prev.EndTime)) {
+			continue
+		}
+		last[r.CaseID] = r
+	}
+
+	IDs := make([]int64, 0, len(last))
+	for id, r := range last {
+		if slices.ContainsFunc(statuses, func(status string) bool {
+			return strings.EqualFold(status, r.Status)
+		}) {
+			IDs = append(IDs, id)
+		}
+	}
+
+	slices.Sort(IDs)
+
+	return IDs
+}
+
+// Stats holds the number of test cases in a run by status
+type Stats struct {
+	Total      int `json:"total"`
+	Untested   int `json:"untested"`
+	Passed     int `json:"passed"`
+	Failed     int `json:"failed"`
+	Blocked    int `json:"blocked"`
+	Skipped    int `json:"skipped"`
+	Retest     int `json:"retest"`
+	InProgress int `json:"in_progress"`
+	Invalid    int `json:"invalid"`
+}
+
+// Filter holds the conditions used to search test runs
+type Filter struct {
+	// Search matches the run title
+	Search string
+	// Status is a comma-separated list of statuses: active, complete, abort
+	Status string
+	// FromStartTime and ToStartTime limit the run start time, as Unix timestamps
+	FromStartTime int64
+	ToStartTime   int64
+	MilestoneID   int64
+	EnvironmentID int64
+	// Tags are matched by the service, a run has to have all of them
+	Tags []string
 }
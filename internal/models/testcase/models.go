@@ -0,0 +1,26 @@
+package testcase
+
+// TestCase is a test case of a project
+type TestCase struct {
+	ID      int64    `json:"id"`
+	Title   string   `json:"title"`
+	SuiteID int64    `json:"suite_id,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// Suite is a test suite of a project. ParentID is 0 for the root suites.
+type Suite struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	ParentID int64  `json:"parent_id,omitempty"`
+}
+
+// Filter selects test cases. Empty values are not used.
+type Filter struct {
+	Search string
+	// Severity and Priority are comma separated lists of values, e.g. "critical,major"
+	Severity string
+	Priority string
+	// Automation is one of is-automated, is-not-automated and to-be-automated
+	Automation string
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNoTerminal reports that a confirmation is needed but the input is not a terminal
+var ErrNoTerminal = errors.New("stdin is not a terminal")
+
+// IsTerminal reports whether r is a terminal
+func IsTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Confirm asks the question on out and reports whether the answer read from in is yes.
+// When in is not a terminal it returns ErrNoTerminal without asking, so that scripts fail
+// with a clear error instead of reading an empty answer.
+func Confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	if !IsTerminal(in) {
+		return false, ErrNoTerminal
+	}
+
+	return confirm(in, out, question)
+}
+
+// confirm asks the question on out and reads a y or yes answer from in
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	if _, err := fmt.Fprintf(out, "%s [y/N]: ", question); err != nil {
+		return false, fmt.Errorf("failed to write confirmation: %w", err)
+	}
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
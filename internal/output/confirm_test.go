@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	_, err := Confirm(strings.NewReader("y\n"), &bytes.Buffer{}, "Delete?")
+	if !errors.Is(err, ErrNoTerminal) {
+		t.Errorf("Confirm() error = %v, want %v", err, ErrNoTerminal)
+	}
+}
+
+func Test_confirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "y", input: "y\n", want: true},
+		{name: "yes", input: " YES \n", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty", input: "\n", want: false},
+		{name: "eof", input: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := confirm(strings.NewReader(tt.input), &out, "Delete 2 test runs?")
+			if err != nil {
+				t.Fatalf("confirm() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+			if out.String() != "Delete 2 test runs? [y/N]: " {
+				t.Errorf("confirm() question = %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	type item struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	}
+
+	items := []item{{ID: 1, Title: "Nightly"}, {ID: 12, Title: "Smoke, API"}}
+	table := Table{
+		Header: []string{"ID", "TITLE"},
+		Rows:   [][]string{{"1", "Nightly"}, {"12", "Smoke, API"}},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "table",
+			format: FormatTable,
+			want:   "ID  TITLE\n1   Nightly\n12  Smoke, API\n",
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			want:   "ID,TITLE\n1,Nightly\n12,\"Smoke, API\"\n",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			want:   "[\n  {\n    \"id\": 1,\n    \"title\": \"Nightly\"\n  },\n  {\n    \"id\": 12,\n    \"title\": \"Smoke, API\"\n  }\n]\n",
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			want:   "- id: 1\n  title: Nightly\n- id: 12\n  title: Smoke, API\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Write(&b, tt.format, items, table); err != nil {
+				t.Fatalf("Write() unexpected error: %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("yaml"); err != nil || f != FormatYAML {
+		t.Errorf("ParseFormat() = %v, %v, want %v", f, err, FormatYAML)
+	}
+
+	_, err := ParseFormat("xml")
+	if err == nil || err.Error() != "unknown output format \"xml\", allowed formats: table, json, yaml, csv" {
+		t.Errorf("ParseFormat() error = %v", err)
+	}
+}
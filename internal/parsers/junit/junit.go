@@ -1,6 +1,7 @@
 package junit
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/xml"
 	"fmt"
@@ -14,6 +15,9 @@ import (
 	models "github.com/qase-tms/qasectl/internal/models/result"
 )
 
+// utf8BOM is the byte order mark some tools prepend to the report
+var utf8BOM = []byte("\xef\xbb\xbf")
+
 // Parser is a parser for Junit XML files
 type Parser struct {
 	path string
@@ -28,57 +32,83 @@ func NewParser(path string) *Parser {
 
 // Parse parses the Junit XML file and returns the results
 func (p *Parser) Parse() ([]models.Result, error) {
-	const op = "parser.parse"
-	logger := slog.With("op", op)
+	results := make([]models.Result, 0)
 
-	var results []models.Result
+	err := p.Stream(func(result models.Result) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Stream parses the Junit XML file or directory and passes every result to emit as soon as
+// its test case has been decoded, so only one test case is held in memory at a time
+func (p *Parser) Stream(emit func(models.Result) error) error {
+	const op = "parser.stream"
+	logger := slog.With("op", op)
 
 	fileInfo, err := os.Stat(p.path)
 	if err != nil {
 		logger.Error("failed to get file info", "error", err)
-		return nil, err
+		return err
 	}
 
-	if fileInfo.IsDir() {
-		err := filepath.Walk(p.path, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				logger.Error("failed to walk path", "error", err)
-				return err
-			}
-			if !info.IsDir() {
-				result, err := p.parseFile(path)
-				if err != nil {
-					logger.Error("failed to parse file", "error", err)
-					return err
-				}
-				results = append(results, result...)
-			}
-			return nil
-		})
+	if !fileInfo.IsDir() {
+		err := p.streamFile(p.path, emit)
 		if err != nil {
-			return nil, err
+			logger.Error("failed to parse file", "error", err)
+			return err
 		}
-	} else {
-		result, err := p.parseFile(p.path)
+		return nil
+	}
+
+	return filepath.Walk(p.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			logger.Error("failed to walk path", "error", err)
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		err = p.streamFile(path, emit)
 		if err != nil {
 			logger.Error("failed to parse file", "error", err)
-			return nil, err
+			return err
 		}
-		results = append(results, result...)
+		return nil
+	})
+}
+
+// parseFile parses a single Junit XML file
+func (p *Parser) parseFile(path string) ([]models.Result, error) {
+	results := make([]models.Result, 0)
+
+	err := p.streamFile(path, func(result models.Result) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return results, nil
 }
 
-// parseFile parses a single Junit XML file
-func (p *Parser) parseFile(path string) ([]models.Result, error) {
-	const op = "parser.parseFile"
+// streamFile decodes a single Junit XML file token by token and emits a result for every test case.
+// The root element must be <testsuites> or <testsuite>; <testsuite> elements may be nested to any depth.
+func (p *Parser) streamFile(path string, emit func(models.Result) error) error {
+	const op = "parser.streamFile"
 	logger := slog.With("op", op)
 
 	xmlFile, err := os.Open(path)
 	if err != nil {
 		logger.Error("failed to open file", "error", err)
-		return nil, err
+		return err
 	}
 	defer func() {
 		err := xmlFile.Close()
@@ -87,108 +117,164 @@ func (p *Parser) parseFile(path string) ([]models.Result, error) {
 		}
 	}()
 
-	byteValue, err := io.ReadAll(xmlFile)
-	if err != nil {
-		logger.Error("failed to read file", "error", err)
-		return nil, err
+	reader := bufio.NewReader(xmlFile)
+	if bom, err := reader.Peek(len(utf8BOM)); err == nil && bytes.Equal(bom, utf8BOM) {
+		_, _ = reader.Discard(len(utf8BOM))
 	}
 
-	byteValue = bytes.TrimPrefix(byteValue, []byte("\xef\xbb\xbf"))
+	decoder := xml.NewDecoder(reader)
 
-	// Try to parse as TestSuites first (multiple test suites)
-	var testSuites TestSuites
-	err = xml.Unmarshal(byteValue, &testSuites)
-	if err == nil {
-		return convertTestSuites(testSuites), nil
-	}
+	var (
+		ctx     suiteContext
+		hasRoot bool
+	)
 
-	// If that fails, try to parse as single TestSuite
-	var testSuite TestSuite
-	err = xml.Unmarshal(byteValue, &testSuite)
-	if err != nil {
-		logger.Error("failed to unmarshal xml", "error", err)
-		return nil, err
-	}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.Error("failed to decode xml", "error", err)
+			return err
+		}
 
-	// Convert single TestSuite to TestSuites format for processing
-	testSuites = TestSuites{
-		TestSuites: []TestSuite{testSuite},
-	}
+		switch el := token.(type) {
+		case xml.StartElement:
+			if !hasRoot {
+				if el.Name.Local != "testsuites" && el.Name.Local != "testsuite" {
+					return fmt.Errorf("expected element type <testsuites> or <testsuite> but have <%s>", el.Name.Local)
+				}
+				hasRoot = true
 
-	return convertTestSuites(testSuites), nil
-}
+				if el.Name.Local == "testsuites" {
+					ctx.root = attrValue(el.Attr, "name")
+					continue
+				}
+			}
 
-// convertTestSuites converts a TestSuites to Results
-func convertTestSuites(testSuites TestSuites) []models.Result {
-	results := make([]models.Result, 0)
+			switch el.Name.Local {
+			case "testsuite":
+				ctx.suites = append(ctx.suites, attrValue(el.Attr, "name"))
+			case "testcase":
+				if len(ctx.suites) == 0 {
+					// Test cases directly under <testsuites> were never part of the JUnit schema
+					if err := decoder.Skip(); err != nil {
+						return err
+					}
+					continue
+				}
 
-	for _, testSuite := range testSuites.TestSuites {
-		for _, testCase := range testSuite.TestCases {
-			testCase := testCase
-			relation := buildSuiteRelation(testSuites, testSuite)
-			signature := fmt.Sprintf("%s::%s::%s::%s", testSuites.Name, testSuite.Name, testCase.ClassName, testCase.Name)
-			status, stackTrace, message := resolveTestCaseStatus(testCase)
+				var testCase TestCase
+				if err := decoder.DecodeElement(&testCase, &el); err != nil {
+					logger.Error("failed to decode test case", "error", err)
+					return err
+				}
 
-			fields := make(map[string]string)
-			for k := range testCase.Properties.Property {
-				if isStepProperty(testCase.Properties.Property[k].Name) {
-					continue
+				if err := emit(convertTestCase(ctx, testCase)); err != nil {
+					return err
+				}
+			default:
+				// Suite level properties, system-out and system-err are not mapped to results
+				if err := decoder.Skip(); err != nil {
+					return err
 				}
-				fields[testCase.Properties.Property[k].Name] = testCase.Properties.Property[k].Value
 			}
-
-			steps := parseSteps(testCase.Properties)
-			duration := testCase.Time * 1000
-			result := models.Result{
-				Title:     testCase.Name,
-				Signature: &signature,
-				Relations: relation,
-				Execution: models.Execution{
-					Duration:   &duration,
-					Status:     status,
-					StackTrace: stackTrace,
-				},
-				Attachments: buildSystemAttachments(testCase),
-				Steps:       steps,
-				StepType:    "text",
-				Params:      make(map[string]string),
-				Muted:       false,
-				Fields:      fields,
-				Message:     message,
+		case xml.EndElement:
+			if el.Name.Local == "testsuite" && len(ctx.suites) > 0 {
+				ctx.suites = ctx.suites[:len(ctx.suites)-1]
 			}
+		}
+	}
+
+	if !hasRoot {
+		return fmt.Errorf("no root element found in %s", path)
+	}
+
+	return nil
+}
+
+// suiteContext describes the position of a test case in the report
+type suiteContext struct {
+	// root is the name of the <testsuites> element
+	root string
+	// suites are the names of the enclosing <testsuite> elements, outermost first
+	suites []string
+}
+
+// attrValue returns the value of the attribute with the given name
+func attrValue(attrs []xml.Attr, name string) string {
+	for _, attr := range attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
 
-			results = append(results, result)
+// convertTestCase converts a TestCase to a Result
+func convertTestCase(ctx suiteContext, testCase TestCase) models.Result {
+	relation := buildSuiteRelation(ctx)
+	signature := fmt.Sprintf("%s::%s::%s::%s", ctx.root, strings.Join(ctx.suites, "::"), testCase.ClassName, testCase.Name)
+	status, stackTrace, message := resolveTestCaseStatus(testCase)
+
+	fields := make(map[string]string)
+	for k := range testCase.Properties.Property {
+		if isStepProperty(testCase.Properties.Property[k].Name) {
+			continue
 		}
+		fields[testCase.Properties.Property[k].Name] = testCase.Properties.Property[k].Value
 	}
 
-	return results
+	steps := parseSteps(testCase.Properties)
+	duration := testCase.Time * 1000
+
+	return models.Result{
+		Title:     testCase.Name,
+		Signature: &signature,
+		Relations: relation,
+		Execution: models.Execution{
+			Duration:   &duration,
+			Status:     status,
+			StackTrace: stackTrace,
+		},
+		Attachments: buildSystemAttachments(testCase),
+		Steps:       steps,
+		StepType:    "text",
+		Params:      make(map[string]string),
+		Muted:       false,
+		Fields:      fields,
+		Message:     message,
+	}
 }
 
 // buildSuiteRelation constructs the suite hierarchy relation for a test case
-func buildSuiteRelation(testSuites TestSuites, testSuite TestSuite) models.Relation {
+func buildSuiteRelation(ctx suiteContext) models.Relation {
 	relation := models.Relation{
 		Suite: models.Suite{
 			Data: []models.SuiteData{},
 		},
 	}
 
-	if testSuites.Name != "" {
+	if ctx.root != "" {
 		relation.Suite.Data = append(relation.Suite.Data, models.SuiteData{
-			Title: testSuites.Name,
+			Title: ctx.root,
 		})
 	}
 
-	parts := strings.Split(testSuite.Name, string(filepath.Separator))
-	if len(parts) > 1 {
-		for _, part := range parts {
+	for _, name := range ctx.suites {
+		parts := strings.Split(name, string(filepath.Separator))
+		if len(parts) > 1 {
+			for _, part := range parts {
+				relation.Suite.Data = append(relation.Suite.Data, models.SuiteData{
+					Title: part,
+				})
+			}
+		} else {
 			relation.Suite.Data = append(relation.Suite.Data, models.SuiteData{
-				Title: part,
+				Title: name,
 			})
 		}
-	} else {
-		relation.Suite.Data = append(relation.Suite.Data, models.SuiteData{
-			Title: testSuite.Name,
-		})
 	}
 
 	return relation
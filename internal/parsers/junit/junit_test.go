@@ -1,9 +1,14 @@
 package junit
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 
 	models "github.com/qase-tms/qasectl/internal/models/result"
@@ -457,3 +462,155 @@ func TestParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Stream_NestedSuites(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "nested.xml")
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Root">
+  <testsuite name="Outer">
+    <properties><property name="ignored" value="1"/></properties>
+    <testcase name="Test1" classname="A" time="0.01"/>
+    <testsuite name="Inner">
+      <testsuite name="Deep">
+        <testcase name="Test2" classname="B" time="0.02">
+          <failure message="boom">trace</failure>
+        </testcase>
+      </testsuite>
+      <testcase name="Test3" classname="C" time="0.03"/>
+    </testsuite>
+    <system-out>suite output</system-out>
+    <testcase name="Test4" classname="D" time="0.04"/>
+  </testsuite>
+</testsuites>`
+	if err := os.WriteFile(tmpFile, []byte(xmlData), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var results []models.Result
+	err := NewParser(tmpFile).Stream(func(r models.Result) error {
+		results = append(results, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		title     string
+		status    string
+		suites    []string
+		signature string
+	}{
+		{"Test1", "passed", []string{"Root", "Outer"}, "Root::Outer::A::Test1"},
+		{"Test2", "failed", []string{"Root", "Outer", "Inner", "Deep"}, "Root::Outer::Inner::Deep::B::Test2"},
+		{"Test3", "passed", []string{"Root", "Outer", "Inner"}, "Root::Outer::Inner::C::Test3"},
+		{"Test4", "passed", []string{"Root", "Outer"}, "Root::Outer::D::Test4"},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Stream() emitted %d results, want %d", len(results), len(expected))
+	}
+
+	for i, want := range expected {
+		got := results[i]
+		if got.Title != want.title {
+			t.Errorf("Result[%d].Title = %v, want %v", i, got.Title, want.title)
+		}
+		if got.Execution.Status != want.status {
+			t.Errorf("Result[%d].Execution.Status = %v, want %v", i, got.Execution.Status, want.status)
+		}
+		if *got.Signature != want.signature {
+			t.Errorf("Result[%d].Signature = %v, want %v", i, *got.Signature, want.signature)
+		}
+		suites := make([]string, 0, len(got.Relations.Suite.Data))
+		for _, s := range got.Relations.Suite.Data {
+			suites = append(suites, s.Title)
+		}
+		if !reflect.DeepEqual(suites, want.suites) {
+			t.Errorf("Result[%d].Relations = %v, want %v", i, suites, want.suites)
+		}
+	}
+}
+
+func TestParser_Stream_EmitError(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "test.xml")
+	xmlData := `<testsuite name="S"><testcase name="T1"/><testcase name="T2"/></testsuite>`
+	if err := os.WriteFile(tmpFile, []byte(xmlData), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	calls := 0
+	stop := errors.New("stop")
+	err := NewParser(tmpFile).Stream(func(r models.Result) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Stream() error = %v, want %v", err, stop)
+	}
+	if calls != 1 {
+		t.Errorf("Stream() called emit %d times, want 1", calls)
+	}
+}
+
+// writeLargeReport writes a JUnit report with the given number of test cases, each carrying
+// a system-out block, and returns its path
+func writeLargeReport(b *testing.B, cases int) string {
+	b.Helper()
+
+	path := filepath.Join(b.TempDir(), "large.xml")
+	f, err := os.Create(path)
+	if err != nil {
+		b.Fatalf("failed to create report: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	w := bufio.NewWriter(f)
+	out := strings.Repeat("log line\n", 100)
+	_, _ = w.WriteString(`<?xml version="1.0" encoding="UTF-8"?><testsuites name="Root"><testsuite name="Suite">`)
+	for i := 0; i < cases; i++ {
+		_, _ = fmt.Fprintf(w, `<testcase name="Test%d" classname="Class" time="0.01"><failure message="failed">trace</failure><system-out>%s</system-out></testcase>`, i, out)
+	}
+	_, _ = w.WriteString(`</testsuite></testsuites>`)
+	if err := w.Flush(); err != nil {
+		b.Fatalf("failed to write report: %v", err)
+	}
+
+	return path
+}
+
+// BenchmarkParser_Stream reports the peak heap observed while streaming reports of growing size.
+// The peak-heap-MB metric stays flat as the report grows because results are not retained.
+func BenchmarkParser_Stream(b *testing.B) {
+	for _, cases := range []int{1000, 10000, 50000} {
+		b.Run(fmt.Sprintf("cases=%d", cases), func(b *testing.B) {
+			path := writeLargeReport(b, cases)
+			p := NewParser(path)
+
+			var peak uint64
+			var ms runtime.MemStats
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+				n := 0
+				err := p.Stream(func(r models.Result) error {
+					n++
+					if n%1000 == 0 {
+						runtime.ReadMemStats(&ms)
+						if ms.HeapAlloc > peak {
+							peak = ms.HeapAlloc
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					b.Fatalf("Stream() unexpected error: %v", err)
+				}
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
@@ -1,9 +1,5 @@
 package junit
 
-import (
-	"encoding/xml"
-)
-
 type Property struct {
 	Name  string `xml:"name,attr"`
 	Value string `xml:"value,attr"`
@@ -43,31 +39,3 @@ type Error struct {
 	Body    string `xml:",chardata"`
 	Type    string `xml:"type,attr"`
 }
-
-type TestSuite struct {
-	XMLName    xml.Name   `xml:"testsuite"`
-	Name       string     `xml:"name,attr"`
-	Tests      int        `xml:"tests,attr"`
-	Failures   int        `xml:"failures,attr"`
-	Errors     int        `xml:"errors,attr"`
-	Skipped    int        `xml:"skipped,attr"`
-	Assertions int        `xml:"assertions,attr"`
-	Time       float64    `xml:"time,attr"`
-	File       string     `xml:"file,attr"`
-	Properties Properties `xml:"properties"`
-	SystemOut  string     `xml:"system-out"`
-	SystemErr  string     `xml:"system-err"`
-	TestCases  []TestCase `xml:"testcase"`
-}
-
-type TestSuites struct {
-	XMLName    xml.Name    `xml:"testsuites"`
-	Name       string      `xml:"name,attr"`
-	Tests      int         `xml:"tests,attr"`
-	Failures   int         `xml:"failures,attr"`
-	Errors     int         `xml:"errors,attr"`
-	Skipped    int         `xml:"skipped,attr"`
-	Assertions int         `xml:"assertions,attr"`
-	Time       float64     `xml:"time,attr"`
-	TestSuites []TestSuite `xml:"testsuite"`
-}
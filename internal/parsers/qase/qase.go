@@ -24,13 +24,28 @@ func NewParser(path string) *Parser {
 
 // Parse parses the Qase file and returns the results
 func (p *Parser) Parse() ([]models.Result, error) {
-	const op = "qase.Parser.Parse"
+	var results []models.Result
+
+	err := p.Stream(func(result models.Result) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Stream parses the Qase files one by one and passes every result to emit right after its file is read
+func (p *Parser) Stream(emit func(models.Result) error) error {
+	const op = "qase.Parser.Stream"
 	logger := slog.With("path", p.path, "op", op)
 
 	var files []string
 	fileInfo, err := os.Stat(p.path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file info: %w", err)
+		return fmt.Errorf("failed to get file info: %w", err)
 	}
 
 	if fileInfo.IsDir() {
@@ -44,7 +59,7 @@ func (p *Parser) Parse() ([]models.Result, error) {
 			return nil
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to walk path: %w", err)
+			return fmt.Errorf("failed to walk path: %w", err)
 		}
 	} else {
 		files = append(files, p.path)
@@ -52,11 +67,9 @@ func (p *Parser) Parse() ([]models.Result, error) {
 
 	if len(files) == 0 {
 		logger.Info("no files found")
-		return nil, nil
+		return nil
 	}
 
-	results := make([]models.Result, 0, len(files))
-
 	for _, file := range files {
 		if filepath.Ext(file) != ".json" {
 			logger.Debug("skipping file. Only support json format", "file", file)
@@ -70,10 +83,13 @@ func (p *Parser) Parse() ([]models.Result, error) {
 			logger.Error("failed to parse file", "file", file, "error", err)
 			continue
 		}
-		results = append(results, result)
+
+		if err := emit(result); err != nil {
+			return err
+		}
 	}
 
-	return results, nil
+	return nil
 }
 
 // parseFile parses a single Qase file
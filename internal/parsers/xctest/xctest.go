@@ -86,6 +86,23 @@ func (p *Parser) Parse() ([]models.Result, error) {
 	return results, nil
 }
 
+// Stream parses the XCTest file and passes every result to emit.
+// xcresulttool returns the whole summary at once, so results are emitted after parsing has finished.
+func (p *Parser) Stream(emit func(models.Result) error) error {
+	results, err := p.Parse()
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if err := emit(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *Parser) readJson(id *string) ([]byte, error) {
 	args := []string{"xcresulttool", "get", "--path", p.path, "--format", "json"}
 	if id != nil {
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/qase-tms/qasectl/internal/models/run"
-	"log/slog"
+	"strings"
 )
 
 // client is a client for env
@@ -13,6 +13,8 @@ import (
 type client interface {
 	CreateEnvironment(ctx context.Context, pc, n, d, s, h string) (run.Environment, error)
 	GetEnvironments(ctx context.Context, projectCode string) ([]run.Environment, error)
+	UpdateEnvironment(ctx context.Context, projectCode string, id int64, u run.EnvironmentUpdate) error
+	DeleteEnvironment(ctx context.Context, projectCode string, id int64) error
 }
 
 // Service is a Service for env
@@ -25,19 +27,134 @@ func NewService(client client) *Service {
 	return &Service{client: client}
 }
 
-// CreateEnvironment creates a new environment
-func (srv *Service) CreateEnvironment(ctx context.Context, pc, n, d, s, h string) (run.Environment, error) {
+// CreateEnvironment returns the environment with the same slug or creates a new one, and the returned bool reports
+// that it already existed. With force, the environment is created without looking up the existing ones.
+func (srv *Service) CreateEnvironment(ctx context.Context, pc, n, d, s, h string, force bool) (run.Environment, bool, error) {
+	if !force {
+		envs, err := srv.client.GetEnvironments(ctx, pc)
+		if err != nil {
+			return run.Environment{}, false, fmt.Errorf("failed to get environments: %w", err)
+		}
+
+		for _, env := range envs {
+			if env.Slug == s {
+				return env, true, nil
+			}
+		}
+	}
+
+	env, err := srv.client.CreateEnvironment(ctx, pc, n, d, s, h)
+	if err != nil {
+		return run.Environment{}, false, err
+	}
+
+	return env, false, nil
+}
+
+// ListEnvironments returns the environments of the project
+func (srv *Service) ListEnvironments(ctx context.Context, pc string) ([]run.Environment, error) {
 	envs, err := srv.client.GetEnvironments(ctx, pc)
 	if err != nil {
-		return run.Environment{}, fmt.Errorf("failed to get environments: %w", err)
+		return nil, fmt.Errorf("failed to get environments: %w", err)
+	}
+
+	return envs, nil
+}
+
+// GetEnvironment returns the environment with the slug. When it does not exist, the error suggests a similar slug.
+func (srv *Service) GetEnvironment(ctx context.Context, pc, slug string) (run.Environment, error) {
+	envs, err := srv.ListEnvironments(ctx, pc)
+	if err != nil {
+		return run.Environment{}, err
 	}
 
 	for _, env := range envs {
-		if env.Slug == s {
-			slog.Info("environment already exists")
+		if env.Slug == slug {
 			return env, nil
 		}
 	}
 
-	return srv.client.CreateEnvironment(ctx, pc, n, d, s, h)
+	slugs := make([]string, 0, len(envs))
+	for _, env := range envs {
+		slugs = append(slugs, env.Slug)
+	}
+
+	if s := suggest(slug, slugs); s != "" {
+		return run.Environment{}, fmt.Errorf("environment %q not found, did you mean %q?", slug, s)
+	}
+
+	if len(slugs) == 0 {
+		return run.Environment{}, fmt.Errorf("environment %q not found, the project has no environments", slug)
+	}
+
+	return run.Environment{}, fmt.Errorf("environment %q not found, available environments: %s", slug, strings.Join(slugs, ", "))
+}
+
+// UpdateEnvironment updates the environment with the slug
+func (srv *Service) UpdateEnvironment(ctx context.Context, pc, slug string, u run.EnvironmentUpdate) error {
+	if u.IsEmpty() {
+		return fmt.Errorf("nothing to update")
+	}
+
+	if strings.Contains(u.Slug, " ") {
+		return fmt.Errorf("slug can't contain spaces")
+	}
+
+	env, err := srv.GetEnvironment(ctx, pc, slug)
+	if err != nil {
+		return err
+	}
+
+	return srv.client.UpdateEnvironment(ctx, pc, env.ID, u)
+}
+
+// DeleteEnvironment deletes the environment with the slug
+func (srv *Service) DeleteEnvironment(ctx context.Context, pc, slug string) error {
+	env, err := srv.GetEnvironment(ctx, pc, slug)
+	if err != nil {
+		return err
+	}
+
+	return srv.client.DeleteEnvironment(ctx, pc, env.ID)
+}
+
+// suggest returns the candidate closest to value, or an empty string when none is close enough to be a typo
+func suggest(value string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := distance(strings.ToLower(value), strings.ToLower(c))
+		if bestDistance == -1 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	if bestDistance == -1 || bestDistance > max(1, len(value)/3) {
+		return ""
+	}
+
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
 }
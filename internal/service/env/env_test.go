@@ -12,16 +12,18 @@ import (
 
 func TestService_CreateEnvironment(t *testing.T) {
 	type args struct {
-		pc string
-		n  string
-		d  string
-		s  string
-		h  string
+		pc    string
+		n     string
+		d     string
+		s     string
+		h     string
+		force bool
 	}
 	tests := []struct {
 		name       string
 		args       args
 		want       run.Environment
+		existed    bool
 		envs       []run.Environment
 		wantErr    bool
 		errGet     error
@@ -64,6 +66,7 @@ func TestService_CreateEnvironment(t *testing.T) {
 				Title: "name",
 				Slug:  "slug",
 			},
+			existed: true,
 			envs: []run.Environment{
 				{
 					ID:    1,
@@ -116,18 +119,35 @@ func TestService_CreateEnvironment(t *testing.T) {
 			errGet:     nil,
 			errMessage: "error",
 		},
+		{
+			name: "success create environment without lookup",
+			args: args{
+				pc:    "projectCode",
+				n:     "name",
+				s:     "slug",
+				force: true,
+			},
+			want: run.Environment{
+				ID:    1,
+				Title: "name",
+				Slug:  "slug",
+			},
+			createUse: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := newFixture(t)
 
-			f.client.EXPECT().GetEnvironments(gomock.Any(), tt.args.pc).Return(tt.envs, tt.errGet)
+			if !tt.args.force {
+				f.client.EXPECT().GetEnvironments(gomock.Any(), tt.args.pc).Return(tt.envs, tt.errGet)
+			}
 			if tt.createUse {
 				f.client.EXPECT().CreateEnvironment(gomock.Any(), tt.args.pc, tt.args.n, tt.args.d, tt.args.s, tt.args.h).Return(tt.want, tt.errCreate)
 			}
 
 			srv := NewService(f.client)
-			got, err := srv.CreateEnvironment(context.Background(), tt.args.pc, tt.args.n, tt.args.d, tt.args.s, tt.args.h)
+			got, existed, err := srv.CreateEnvironment(context.Background(), tt.args.pc, tt.args.n, tt.args.d, tt.args.s, tt.args.h, tt.args.force)
 			if err != nil {
 				if !tt.wantErr {
 					t.Errorf("CreateEnvironment() error = %v, wantErr %v", err, tt.wantErr)
@@ -139,6 +159,102 @@ func TestService_CreateEnvironment(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CreateEnvironment() got = %v, want %v", got, tt.want)
 			}
+			assert.Equal(t, existed, tt.existed)
+		})
+	}
+}
+
+func TestService_GetEnvironment(t *testing.T) {
+	envs := []run.Environment{
+		{ID: 1, Title: "Staging", Slug: "staging"},
+		{ID: 2, Title: "Production", Slug: "production"},
+	}
+
+	tests := []struct {
+		name       string
+		slug       string
+		envs       []run.Environment
+		want       run.Environment
+		errMessage string
+	}{
+		{
+			name: "found",
+			slug: "production",
+			envs: envs,
+			want: envs[1],
+		},
+		{
+			name:       "typo",
+			slug:       "stagign",
+			envs:       envs,
+			errMessage: "environment \"stagign\" not found, did you mean \"staging\"?",
+		},
+		{
+			name:       "unknown",
+			slug:       "local",
+			envs:       envs,
+			errMessage: "environment \"local\" not found, available environments: staging, production",
+		},
+		{
+			name:       "no environments",
+			slug:       "local",
+			errMessage: "environment \"local\" not found, the project has no environments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.client.EXPECT().GetEnvironments(gomock.Any(), "projectCode").Return(tt.envs, nil)
+
+			srv := NewService(f.client)
+			got, err := srv.GetEnvironment(context.Background(), "projectCode", tt.slug)
+			if tt.errMessage != "" {
+				if err == nil {
+					t.Fatalf("GetEnvironment() error = nil, want %v", tt.errMessage)
+				}
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetEnvironment() error = %v", err)
+			}
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestService_UpdateEnvironment(t *testing.T) {
+	f := newFixture(t)
+	u := run.EnvironmentUpdate{Host: "staging.example.com"}
+	f.client.EXPECT().GetEnvironments(gomock.Any(), "projectCode").Return([]run.Environment{{ID: 3, Slug: "staging"}}, nil)
+	f.client.EXPECT().UpdateEnvironment(gomock.Any(), "projectCode", int64(3), u).Return(nil)
+
+	srv := NewService(f.client)
+	if err := srv.UpdateEnvironment(context.Background(), "projectCode", "staging", u); err != nil {
+		t.Errorf("UpdateEnvironment() error = %v", err)
+	}
+
+	err := srv.UpdateEnvironment(context.Background(), "projectCode", "staging", run.EnvironmentUpdate{})
+	if err == nil || err.Error() != "nothing to update" {
+		t.Errorf("UpdateEnvironment() error = %v, want nothing to update", err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "prod", want: ""},
+		{value: "Staging", want: "staging"},
+		{value: "stagin", want: "staging"},
+		{value: "qa", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := suggest(tt.value, []string{"staging", "production"}); got != tt.want {
+				t.Errorf("suggest() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
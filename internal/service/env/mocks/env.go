@@ -55,6 +55,20 @@ func (mr *MockclientMockRecorder) CreateEnvironment(ctx, pc, n, d, s, h any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*Mockclient)(nil).CreateEnvironment), ctx, pc, n, d, s, h)
 }
 
+// DeleteEnvironment mocks base method.
+func (m *Mockclient) DeleteEnvironment(ctx context.Context, projectCode string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEnvironment", ctx, projectCode, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEnvironment indicates an expected call of DeleteEnvironment.
+func (mr *MockclientMockRecorder) DeleteEnvironment(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*Mockclient)(nil).DeleteEnvironment), ctx, projectCode, id)
+}
+
 // GetEnvironments mocks base method.
 func (m *Mockclient) GetEnvironments(ctx context.Context, projectCode string) ([]run.Environment, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +83,17 @@ func (mr *MockclientMockRecorder) GetEnvironments(ctx, projectCode any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironments", reflect.TypeOf((*Mockclient)(nil).GetEnvironments), ctx, projectCode)
 }
+
+// UpdateEnvironment mocks base method.
+func (m *Mockclient) UpdateEnvironment(ctx context.Context, projectCode string, id int64, u run.EnvironmentUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvironment", ctx, projectCode, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvironment indicates an expected call of UpdateEnvironment.
+func (mr *MockclientMockRecorder) UpdateEnvironment(ctx, projectCode, id, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*Mockclient)(nil).UpdateEnvironment), ctx, projectCode, id, u)
+}
@@ -0,0 +1,196 @@
+package fields
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a change of a custom field needed to sync the workspace with a spec
+type Change struct {
+	Action string             `json:"action" yaml:"action"`
+	Field  custom.CustomField `json:"field" yaml:"field"`
+	// Diff describes the changed attributes of an updated field
+	Diff []string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// PlanCustomFields compares the spec with the custom fields of the workspace and returns the changes to sync them:
+// missing fields are created, changed fields are updated and, with prune, fields missing in the spec are deleted.
+// Fields are matched by entity and case-insensitive title.
+//
+// Custom fields are shared by all projects of the workspace, so prune only deletes fields bound to the projects of
+// the spec. Fields enabled for all projects or for other projects, and fields whose title is duplicated, are kept.
+func (s *Service) PlanCustomFields(ctx context.Context, spec custom.Spec, prune bool) ([]Change, error) {
+	const op = "fields.custom.plancustomfields"
+	logger := slog.With("op", op)
+
+	existing, err := s.client.GetCustomFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	index := indexFields(existing)
+	matched := make(map[int64]bool, len(spec.Fields))
+
+	var creates, updates, deletes []Change
+	for _, field := range spec.Fields {
+		e, ok := index[fieldKey(field)]
+		if !ok {
+			creates = append(creates, Change{Action: ActionCreate, Field: field})
+			continue
+		}
+		matched[e.ID] = true
+
+		if e.Type != field.Type {
+			return nil, fmt.Errorf("custom field %q of %s has type %s, it cannot be changed to %s", e.Title, e.Entity, e.Type, field.Type)
+		}
+
+		field.ID = e.ID
+		field.OptionIDs = e.OptionIDs
+		if diff := diffFields(e, field); len(diff) > 0 {
+			updates = append(updates, Change{Action: ActionUpdate, Field: field, Diff: diff})
+		}
+	}
+
+	if prune {
+		scope := specProjects(spec)
+		counts := make(map[string]int, len(existing))
+		for _, e := range existing {
+			counts[fieldKey(e)]++
+		}
+
+		for _, e := range existing {
+			switch {
+			case matched[e.ID]:
+			case counts[fieldKey(e)] > 1:
+				logger.Warn("not pruning custom field with a duplicated title", "id", e.ID, "title", e.Title, "entity", e.Entity)
+			case !boundTo(e, scope):
+				logger.Debug("not pruning custom field of other projects", "id", e.ID, "title", e.Title, "projects", e.Projects)
+			default:
+				deletes = append(deletes, Change{Action: ActionDelete, Field: e})
+			}
+		}
+	}
+
+	changes := slices.Concat(creates, updates, deletes)
+
+	logger.Debug("planned custom field changes", "create", len(creates), "update", len(updates), "delete", len(deletes))
+
+	return changes, nil
+}
+
+// ApplyCustomFields applies the changes in order and stops at the first failure
+func (s *Service) ApplyCustomFields(ctx context.Context, changes []Change) error {
+	const op = "fields.custom.applycustomfields"
+	logger := slog.With("op", op)
+
+	for _, c := range changes {
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			_, err = s.client.CreateCustomField(ctx, c.Field)
+		case ActionUpdate:
+			err = s.client.UpdateCustomField(ctx, c.Field.ID, c.Field)
+		case ActionDelete:
+			err = s.client.RemoveCustomFieldByID(ctx, int32(c.Field.ID))
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s custom field %q: %w", c.Action, c.Field.Title, err)
+		}
+
+		logger.Debug("applied custom field change", "action", c.Action, "title", c.Field.Title)
+	}
+
+	return nil
+}
+
+// diffFields returns the attributes of the field that differ from the existing one
+func diffFields(existing, field custom.CustomField) []string {
+	diff := make([]string, 0)
+	add := func(name string, from, to any) {
+		diff = append(diff, fmt.Sprintf("%s: %v -> %v", name, from, to))
+	}
+
+	if existing.Title != field.Title {
+		add("title", existing.Title, field.Title)
+	}
+	if !slices.Equal(existing.Options, field.Options) {
+		add("options", existing.Options, field.Options)
+	}
+	if existing.Placeholder != field.Placeholder {
+		add("placeholder", existing.Placeholder, field.Placeholder)
+	}
+	if existing.DefaultValue != field.DefaultValue {
+		add("default_value", existing.DefaultValue, field.DefaultValue)
+	}
+	if existing.Required != field.Required {
+		add("required", existing.Required, field.Required)
+	}
+	if existing.Hidden != field.Hidden {
+		add("hidden", existing.Hidden, field.Hidden)
+	}
+	if existing.Filterable != field.Filterable {
+		add("filterable", existing.Filterable, field.Filterable)
+	}
+	if existing.AllProjects != field.AllProjects {
+		add("all_projects", existing.AllProjects, field.AllProjects)
+	}
+	if !field.AllProjects && !equalProjects(existing.Projects, field.Projects) {
+		add("projects", existing.Projects, field.Projects)
+	}
+
+	return diff
+}
+
+// specProjects returns the upper-cased codes of the projects the fields of the spec are bound to
+func specProjects(spec custom.Spec) map[string]bool {
+	projects := make(map[string]bool)
+	for _, f := range spec.Fields {
+		for _, code := range f.Projects {
+			projects[strings.ToUpper(code)] = true
+		}
+	}
+
+	return projects
+}
+
+// boundTo reports whether the field is bound only to projects in scope
+func boundTo(f custom.CustomField, scope map[string]bool) bool {
+	if f.AllProjects || len(f.Projects) == 0 {
+		return false
+	}
+
+	for _, code := range f.Projects {
+		if !scope[strings.ToUpper(code)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalProjects reports whether both lists hold the same project codes in any order
+func equalProjects(a, b []string) bool {
+	normalize := func(codes []string) []string {
+		n := make([]string, 0, len(codes))
+		for _, code := range codes {
+			n = append(n, strings.ToUpper(code))
+		}
+		slices.Sort(n)
+		return n
+	}
+
+	return slices.Equal(normalize(a), normalize(b))
+}
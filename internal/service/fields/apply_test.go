@@ -0,0 +1,177 @@
+package fields
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+	"github.com/qase-tms/qasectl/internal/service/fields/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_PlanCustomFields(t *testing.T) {
+	existing := []custom.CustomField{
+		{ID: 1, Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API"}, OptionIDs: map[string]int64{"E2E": 1, "API": 2}, Projects: []string{"PRJ"}},
+		{ID: 2, Title: "Build", Entity: "run", Type: "string", AllProjects: true},
+		{ID: 3, Title: "Legacy", Entity: "case", Type: "text", AllProjects: true},
+	}
+
+	tests := []struct {
+		name       string
+		spec       custom.Spec
+		prune      bool
+		fields     []custom.CustomField
+		fieldsErr  error
+		want       []Change
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "nothing to change",
+			spec: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API"}, Projects: []string{"prj"}},
+			}},
+			fields: existing[:1],
+			want:   nil,
+		},
+		{
+			name: "create, update and keep unknown fields",
+			spec: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API", "Unit"}, Projects: []string{"PRJ", "DEMO"}},
+				{Title: "Build", Entity: "run", Type: "string", AllProjects: true},
+				{Title: "Layer", Entity: "defect", Type: "string", AllProjects: true},
+			}},
+			fields: existing,
+			want: []Change{
+				{Action: ActionCreate, Field: custom.CustomField{Title: "Layer", Entity: "defect", Type: "string", AllProjects: true}},
+				{
+					Action: ActionUpdate,
+					Field:  custom.CustomField{ID: 1, Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API", "Unit"}, OptionIDs: map[string]int64{"E2E": 1, "API": 2}, Projects: []string{"PRJ", "DEMO"}},
+					Diff:   []string{"options: [E2E API] -> [E2E API Unit]", "projects: [PRJ] -> [PRJ DEMO]"},
+				},
+			},
+		},
+		{
+			name: "prune fields of the spec projects",
+			spec: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Build", Entity: "run", Type: "string", Required: true, AllProjects: true},
+				{Title: "Owner", Entity: "case", Type: "user", Projects: []string{"prj"}},
+			}},
+			prune: true,
+			fields: []custom.CustomField{
+				existing[0],
+				existing[1],
+				existing[2],
+				{ID: 4, Title: "Owner", Entity: "case", Type: "user", Projects: []string{"PRJ"}},
+				{ID: 5, Title: "owner", Entity: "case", Type: "user", Projects: []string{"PRJ"}},
+				{ID: 6, Title: "Team", Entity: "case", Type: "string", Projects: []string{"PRJ", "DEMO"}},
+				{ID: 7, Title: "Unbound", Entity: "case", Type: "string"},
+			},
+			want: []Change{
+				{Action: ActionUpdate, Field: custom.CustomField{ID: 2, Title: "Build", Entity: "run", Type: "string", Required: true, AllProjects: true}, Diff: []string{"required: false -> true"}},
+				{Action: ActionDelete, Field: existing[0]},
+			},
+		},
+		{
+			name: "prune nothing without spec projects",
+			spec: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Build", Entity: "run", Type: "string", AllProjects: true},
+			}},
+			prune:  true,
+			fields: existing,
+			want:   nil,
+		},
+		{
+			name: "type cannot be changed",
+			spec: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Legacy", Entity: "case", Type: "string", AllProjects: true},
+			}},
+			fields:     existing,
+			wantErr:    true,
+			errMessage: `custom field "Legacy" of case has type text, it cannot be changed to string`,
+		},
+		{
+			name:       "failed to get custom fields",
+			spec:       custom.Spec{},
+			fieldsErr:  errors.New("api error"),
+			wantErr:    true,
+			errMessage: "failed to get custom fields: api error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.client.EXPECT().GetCustomFields(gomock.Any()).Return(tt.fields, tt.fieldsErr)
+
+			srv := NewService(f.client)
+			got, err := srv.PlanCustomFields(context.Background(), tt.spec, tt.prune)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if err.Error() != tt.errMessage {
+					t.Errorf("PlanCustomFields() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanCustomFields() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ApplyCustomFields(t *testing.T) {
+	changes := []Change{
+		{Action: ActionCreate, Field: custom.CustomField{Title: "New", Entity: "case", Type: "string"}},
+		{Action: ActionUpdate, Field: custom.CustomField{ID: 2, Title: "Build", Entity: "run", Type: "string"}},
+		{Action: ActionDelete, Field: custom.CustomField{ID: 3, Title: "Legacy", Entity: "case", Type: "text"}},
+	}
+
+	tests := []struct {
+		name       string
+		mockSetup  func(*mocks.Mockclient)
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "success",
+			mockSetup: func(m *mocks.Mockclient) {
+				gomock.InOrder(
+					m.EXPECT().CreateCustomField(gomock.Any(), changes[0].Field).Return(int64(4), nil),
+					m.EXPECT().UpdateCustomField(gomock.Any(), int64(2), changes[1].Field).Return(nil),
+					m.EXPECT().RemoveCustomFieldByID(gomock.Any(), int32(3)).Return(nil),
+				)
+			},
+		},
+		{
+			name: "stops at the first failure",
+			mockSetup: func(m *mocks.Mockclient) {
+				m.EXPECT().CreateCustomField(gomock.Any(), changes[0].Field).Return(int64(4), nil)
+				m.EXPECT().UpdateCustomField(gomock.Any(), int64(2), changes[1].Field).Return(errors.New("api error"))
+			},
+			wantErr:    true,
+			errMessage: `failed to update custom field "Build": api error`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tt.mockSetup(f.client)
+
+			srv := NewService(f.client)
+			err := srv.ApplyCustomFields(context.Background(), changes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err.Error() != tt.errMessage {
+				t.Errorf("ApplyCustomFields() error = %v, want %v", err, tt.errMessage)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package fields
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/qase-tms/qasectl/internal/models/fields/custom"
 )
@@ -11,6 +14,8 @@ import (
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type client interface {
 	GetCustomFields(ctx context.Context) ([]custom.CustomField, error)
+	CreateCustomField(ctx context.Context, f custom.CustomField) (int64, error)
+	UpdateCustomField(ctx context.Context, id int64, f custom.CustomField) error
 	RemoveCustomFieldByID(ctx context.Context, fieldID int32) error
 }
 
@@ -50,3 +55,53 @@ func (s *Service) RemoveCustomFields(ctx context.Context, params RemoveCustomFie
 
 	return nil
 }
+
+// ListCustomFields returns the custom fields of the workspace sorted by entity and title
+func (s *Service) ListCustomFields(ctx context.Context) ([]custom.CustomField, error) {
+	fields, err := s.client.GetCustomFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	slices.SortStableFunc(fields, func(a, b custom.CustomField) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(custom.Entities, a.Entity), slices.Index(custom.Entities, b.Entity)),
+			cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)),
+		)
+	})
+
+	return fields, nil
+}
+
+// CreateCustomFields creates the custom fields of the spec and returns them with their IDs.
+// Nothing is created when a field of the spec already exists, use PlanCustomFields to sync existing fields.
+func (s *Service) CreateCustomFields(ctx context.Context, spec custom.Spec) ([]custom.CustomField, error) {
+	const op = "fields.custom.createcustomfields"
+	logger := slog.With("op", op)
+
+	logger.Debug("creating custom fields", "spec", spec)
+
+	existing, err := s.client.GetCustomFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	index := indexFields(existing)
+	for _, field := range spec.Fields {
+		if e, ok := index[fieldKey(field)]; ok {
+			return nil, fmt.Errorf("custom field %q of %s already exists with ID %d, use apply to update it", field.Title, field.Entity, e.ID)
+		}
+	}
+
+	created := make([]custom.CustomField, 0, len(spec.Fields))
+	for _, field := range spec.Fields {
+		id, err := s.client.CreateCustomField(ctx, field)
+		if err != nil {
+			return created, fmt.Errorf("failed to create custom field %q: %w", field.Title, err)
+		}
+		field.ID = id
+		created = append(created, field)
+	}
+
+	return created, nil
+}
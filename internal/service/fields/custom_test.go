@@ -3,6 +3,7 @@ package fields
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/qase-tms/qasectl/internal/models/fields/custom"
@@ -159,3 +160,71 @@ func TestService_RemoveCustomFields(t *testing.T) {
 		})
 	}
 }
+
+func TestService_CreateCustomFields(t *testing.T) {
+	spec := custom.Spec{Fields: []custom.CustomField{
+		{Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E"}},
+		{Title: "Build", Entity: "run", Type: "string"},
+	}}
+
+	tests := []struct {
+		name       string
+		mockSetup  func(*mocks.Mockclient)
+		want       []custom.CustomField
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "success",
+			mockSetup: func(m *mocks.Mockclient) {
+				m.EXPECT().GetCustomFields(gomock.Any()).Return([]custom.CustomField{{ID: 1, Title: "Layer", Entity: "defect", Type: "string"}}, nil)
+				m.EXPECT().CreateCustomField(gomock.Any(), spec.Fields[0]).Return(int64(2), nil)
+				m.EXPECT().CreateCustomField(gomock.Any(), spec.Fields[1]).Return(int64(3), nil)
+			},
+			want: []custom.CustomField{
+				{ID: 2, Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E"}},
+				{ID: 3, Title: "Build", Entity: "run", Type: "string"},
+			},
+		},
+		{
+			name: "field already exists",
+			mockSetup: func(m *mocks.Mockclient) {
+				m.EXPECT().GetCustomFields(gomock.Any()).Return([]custom.CustomField{{ID: 1, Title: "build", Entity: "run", Type: "string"}}, nil)
+			},
+			wantErr:    true,
+			errMessage: `custom field "Build" of run already exists with ID 1, use apply to update it`,
+		},
+		{
+			name: "failed to create custom field",
+			mockSetup: func(m *mocks.Mockclient) {
+				m.EXPECT().GetCustomFields(gomock.Any()).Return(nil, nil)
+				m.EXPECT().CreateCustomField(gomock.Any(), spec.Fields[0]).Return(int64(0), errors.New("api error"))
+			},
+			wantErr:    true,
+			errMessage: `failed to create custom field "Layer": api error`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tt.mockSetup(f.client)
+
+			srv := NewService(f.client)
+			got, err := srv.CreateCustomFields(context.Background(), spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if err.Error() != tt.errMessage {
+					t.Errorf("CreateCustomFields() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateCustomFields() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -40,6 +40,21 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 	return m.recorder
 }
 
+// CreateCustomField mocks base method.
+func (m *Mockclient) CreateCustomField(ctx context.Context, f custom.CustomField) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", ctx, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockclientMockRecorder) CreateCustomField(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*Mockclient)(nil).CreateCustomField), ctx, f)
+}
+
 // GetCustomFields mocks base method.
 func (m *Mockclient) GetCustomFields(ctx context.Context) ([]custom.CustomField, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +83,17 @@ func (mr *MockclientMockRecorder) RemoveCustomFieldByID(ctx, fieldID any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCustomFieldByID", reflect.TypeOf((*Mockclient)(nil).RemoveCustomFieldByID), ctx, fieldID)
 }
+
+// UpdateCustomField mocks base method.
+func (m *Mockclient) UpdateCustomField(ctx context.Context, id int64, f custom.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", ctx, id, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockclientMockRecorder) UpdateCustomField(ctx, id, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*Mockclient)(nil).UpdateCustomField), ctx, id, f)
+}
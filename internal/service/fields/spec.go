@@ -0,0 +1,161 @@
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// SpecFormats are the supported formats of the custom field specs
+var SpecFormats = []string{FormatYAML, FormatJSON}
+
+// ReadSpec reads a custom field spec in YAML or JSON and validates it.
+// The entity defaults to case, entities and types are case-insensitive.
+func ReadSpec(r io.Reader) (custom.Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return custom.Spec{}, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	// Unknown keys are rejected, otherwise a misspelled attribute would be applied as its zero value
+	var spec custom.Spec
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		err = d.Decode(&spec)
+	} else {
+		d := yaml.NewDecoder(bytes.NewReader(data))
+		d.KnownFields(true)
+		err = d.Decode(&spec)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return custom.Spec{}, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	for i := range spec.Fields {
+		f := &spec.Fields[i]
+		f.ID = 0
+		f.Title = strings.TrimSpace(f.Title)
+		f.Entity = strings.ToLower(f.Entity)
+		f.Type = strings.ToLower(f.Type)
+		if f.Entity == "" {
+			f.Entity = custom.Entities[0]
+		}
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return custom.Spec{}, err
+	}
+
+	return spec, nil
+}
+
+// WriteSpec writes the spec to w in the given format
+func WriteSpec(w io.Writer, format string, spec custom.Spec) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case FormatYAML:
+		data, err = yaml.Marshal(spec)
+	case FormatJSON:
+		data, err = json.MarshalIndent(spec, "", "  ")
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown spec format %q, allowed formats: %s", format, strings.Join(SpecFormats, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+
+	return nil
+}
+
+// ExportSpec returns the spec of the custom fields. The IDs are dropped so that the spec
+// can be applied to another workspace.
+func ExportSpec(fields []custom.CustomField) custom.Spec {
+	spec := custom.Spec{Fields: make([]custom.CustomField, 0, len(fields))}
+	for _, f := range fields {
+		f.ID = 0
+		f.OptionIDs = nil
+		if f.AllProjects {
+			f.Projects = nil
+		}
+		spec.Fields = append(spec.Fields, f)
+	}
+
+	return spec
+}
+
+// validateSpec checks the titles, entities, types and options of the fields
+func validateSpec(spec custom.Spec) error {
+	seen := make(map[string]bool, len(spec.Fields))
+	for i, f := range spec.Fields {
+		if f.Title == "" {
+			return fmt.Errorf("field %d: title is required", i+1)
+		}
+
+		if !slices.Contains(custom.Entities, f.Entity) {
+			return fmt.Errorf("field %q: unknown entity %q, allowed entities: %s", f.Title, f.Entity, strings.Join(custom.Entities, ", "))
+		}
+
+		if !slices.Contains(custom.Types, f.Type) {
+			return fmt.Errorf("field %q: unknown type %q, allowed types: %s", f.Title, f.Type, strings.Join(custom.Types, ", "))
+		}
+
+		hasOptions := slices.Contains(custom.OptionTypes, f.Type)
+		if hasOptions && len(f.Options) == 0 {
+			return fmt.Errorf("field %q: options are required for type %s", f.Title, f.Type)
+		}
+		if !hasOptions && len(f.Options) > 0 {
+			return fmt.Errorf("field %q: options are only supported for types %s", f.Title, strings.Join(custom.OptionTypes, ", "))
+		}
+
+		if f.AllProjects && len(f.Projects) > 0 {
+			return fmt.Errorf("field %q: projects cannot be set together with all_projects", f.Title)
+		}
+
+		key := fieldKey(f)
+		if seen[key] {
+			return fmt.Errorf("field %q of %s is defined more than once", f.Title, f.Entity)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// fieldKey identifies a custom field by its entity and case-insensitive title
+func fieldKey(f custom.CustomField) string {
+	return f.Entity + "/" + strings.ToLower(strings.TrimSpace(f.Title))
+}
+
+// indexFields returns the fields by their keys. The first field wins when titles are duplicated.
+func indexFields(fields []custom.CustomField) map[string]custom.CustomField {
+	index := make(map[string]custom.CustomField, len(fields))
+	for _, f := range fields {
+		if _, ok := index[fieldKey(f)]; !ok {
+			index[fieldKey(f)] = f
+		}
+	}
+
+	return index
+}
@@ -0,0 +1,165 @@
+package fields
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+)
+
+func TestReadSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       custom.Spec
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "yaml spec",
+			input: `fields:
+  - title: Layer
+    type: Selectbox
+    options: [E2E, API]
+    projects: [PRJ]
+  - title: Build
+    entity: run
+    type: string
+    required: true
+`,
+			want: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API"}, Projects: []string{"PRJ"}},
+				{Title: "Build", Entity: "run", Type: "string", Required: true},
+			}},
+		},
+		{
+			name:  "json spec",
+			input: `{"fields": [{"id": 7, "title": " Layer ", "type": "radio", "options": ["E2E"], "all_projects": true}]}`,
+			want: custom.Spec{Fields: []custom.CustomField{
+				{Title: "Layer", Entity: "case", Type: "radio", Options: []string{"E2E"}, AllProjects: true},
+			}},
+		},
+		{
+			name:       "missing title",
+			input:      `fields: [{type: string}]`,
+			wantErr:    true,
+			errMessage: "field 1: title is required",
+		},
+		{
+			name:       "unknown entity",
+			input:      `fields: [{title: Layer, entity: plan, type: string}]`,
+			wantErr:    true,
+			errMessage: `field "Layer": unknown entity "plan", allowed entities: case, run, defect`,
+		},
+		{
+			name:       "unknown type",
+			input:      `fields: [{title: Layer, type: list}]`,
+			wantErr:    true,
+			errMessage: `field "Layer": unknown type "list"`,
+		},
+		{
+			name:       "missing options",
+			input:      `fields: [{title: Layer, type: selectbox}]`,
+			wantErr:    true,
+			errMessage: `field "Layer": options are required for type selectbox`,
+		},
+		{
+			name:       "options of a string field",
+			input:      `fields: [{title: Layer, type: string, options: [E2E]}]`,
+			wantErr:    true,
+			errMessage: `field "Layer": options are only supported for types`,
+		},
+		{
+			name:       "projects with all projects",
+			input:      `fields: [{title: Layer, type: string, projects: [PRJ], all_projects: true}]`,
+			wantErr:    true,
+			errMessage: `field "Layer": projects cannot be set together with all_projects`,
+		},
+		{
+			name:       "duplicated field",
+			input:      `fields: [{title: Layer, type: string}, {title: layer, entity: case, type: text}]`,
+			wantErr:    true,
+			errMessage: `field "layer" of case is defined more than once`,
+		},
+		{
+			name:       "unknown yaml key",
+			input:      "fields:\n  - title: Layer\n    type: string\n    hiden: true\n",
+			wantErr:    true,
+			errMessage: "field hiden not found",
+		},
+		{
+			name:       "unknown json key",
+			input:      `{"fields": [{"title": "Layer", "type": "string", "all_project": true}]}`,
+			wantErr:    true,
+			errMessage: `unknown field "all_project"`,
+		},
+		{
+			name:  "empty spec",
+			input: "",
+			want:  custom.Spec{},
+		},
+		{
+			name:       "invalid spec",
+			input:      `fields: {title: Layer}`,
+			wantErr:    true,
+			errMessage: "failed to parse spec",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSpec(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errMessage) {
+					t.Errorf("ReadSpec() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadSpec() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteSpec(t *testing.T) {
+	fields := []custom.CustomField{
+		{ID: 1, Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API"}, OptionIDs: map[string]int64{"E2E": 1, "API": 2}, AllProjects: true, Projects: []string{"PRJ"}},
+		{ID: 2, Title: "Build", Entity: "run", Type: "string", Hidden: true, Projects: []string{"PRJ", "DEMO"}},
+	}
+	want := custom.Spec{Fields: []custom.CustomField{
+		{Title: "Layer", Entity: "case", Type: "selectbox", Options: []string{"E2E", "API"}, AllProjects: true},
+		{Title: "Build", Entity: "run", Type: "string", Hidden: true, Projects: []string{"PRJ", "DEMO"}},
+	}}
+
+	for _, format := range SpecFormats {
+		t.Run(format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := WriteSpec(&b, format, ExportSpec(fields)); err != nil {
+				t.Fatalf("WriteSpec() error = %v", err)
+			}
+
+			got, err := ReadSpec(&b)
+			if err != nil {
+				t.Fatalf("ReadSpec() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadSpec() got = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := WriteSpec(&bytes.Buffer{}, "toml", custom.Spec{})
+		if err == nil || !strings.Contains(err.Error(), `unknown spec format "toml"`) {
+			t.Errorf("WriteSpec() error = %v", err)
+		}
+	})
+}
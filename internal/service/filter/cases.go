@@ -0,0 +1,134 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/qase-tms/qasectl/internal/models/testcase"
+)
+
+// automations maps the automation statuses accepted by the filter to the API values
+var automations = map[string]string{
+	"automated":       "is-automated",
+	"manual":          "is-not-automated",
+	"to-be-automated": "to-be-automated",
+}
+
+// CaseFilter selects the test cases of a project. Cases have to match all the given selectors.
+type CaseFilter struct {
+	// SuiteID selects the cases of the suite and its nested suites
+	SuiteID int64
+	// Tags selects the cases having all of these tags
+	Tags     []string
+	Priority []string
+	Severity []string
+	// Automation is one of automated, manual and to-be-automated
+	Automation string
+	// Query is a free-text search in the case titles
+	Query string
+}
+
+// IsEmpty reports whether the filter selects nothing
+func (f CaseFilter) IsEmpty() bool {
+	return f.SuiteID == 0 && len(f.Tags) == 0 && len(f.Priority) == 0 && len(f.Severity) == 0 &&
+		f.Automation == "" && f.Query == ""
+}
+
+// getCases returns the sorted IDs of the cases matching the filter
+func (s *Service) getCases(ctx context.Context, project string, f CaseFilter) ([]int64, error) {
+	automation := ""
+	if f.Automation != "" {
+		a, ok := automations[f.Automation]
+		if !ok {
+			return nil, fmt.Errorf("unknown automation status %q, allowed statuses: automated, manual, to-be-automated", f.Automation)
+		}
+		automation = a
+	}
+
+	var suites map[int64]bool
+	if f.SuiteID != 0 {
+		all, err := s.client.GetSuites(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suites: %w", err)
+		}
+
+		suites = nestedSuites(all, f.SuiteID)
+		if len(suites) == 0 {
+			return nil, fmt.Errorf("suite %d not found", f.SuiteID)
+		}
+	}
+
+	cases, err := s.client.GetCases(ctx, project, testcase.Filter{
+		Search:     f.Query,
+		Severity:   strings.Join(f.Severity, ","),
+		Priority:   strings.Join(f.Priority, ","),
+		Automation: automation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test cases: %w", err)
+	}
+
+	IDs := make([]int64, 0, len(cases))
+	for _, tc := range cases {
+		if suites != nil && !suites[tc.SuiteID] {
+			continue
+		}
+		if !hasTags(tc, f.Tags) {
+			continue
+		}
+		IDs = append(IDs, tc.ID)
+	}
+
+	if len(IDs) == 0 {
+		return nil, fmt.Errorf("no cases found matching the filters")
+	}
+
+	slices.Sort(IDs)
+
+	return IDs, nil
+}
+
+// nestedSuites returns the ID of the suite and the IDs of all its nested suites.
+// It returns an empty set when the suite does not exist.
+func nestedSuites(suites []testcase.Suite, suiteID int64) map[int64]bool {
+	children := make(map[int64][]int64, len(suites))
+	found := false
+	for _, suite := range suites {
+		children[suite.ParentID] = append(children[suite.ParentID], suite.ID)
+		if suite.ID == suiteID {
+			found = true
+		}
+	}
+
+	IDs := make(map[int64]bool)
+	if !found {
+		return IDs
+	}
+
+	queue := []int64{suiteID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if IDs[id] {
+			continue
+		}
+		IDs[id] = true
+		queue = append(queue, children[id]...)
+	}
+
+	return IDs
+}
+
+// hasTags reports whether the case has all the tags
+func hasTags(tc testcase.TestCase, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(tc.Tags, func(t string) bool {
+			return strings.EqualFold(t, tag)
+		}) {
+			return false
+		}
+	}
+	return true
+}
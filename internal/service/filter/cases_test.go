@@ -0,0 +1,157 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qase-tms/qasectl/internal/models/testcase"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_GetFilter_Cases(t *testing.T) {
+	suites := []testcase.Suite{
+		{ID: 1, Title: "Auth"},
+		{ID: 2, Title: "Login", ParentID: 1},
+		{ID: 3, Title: "SSO", ParentID: 2},
+		{ID: 4, Title: "Billing"},
+	}
+	cases := []testcase.TestCase{
+		{ID: 10, SuiteID: 1, Tags: []string{"smoke"}},
+		{ID: 11, SuiteID: 3, Tags: []string{"Smoke", "sso"}},
+		{ID: 12, SuiteID: 4, Tags: []string{"smoke"}},
+		{ID: 13, SuiteID: 2},
+	}
+
+	tests := []struct {
+		name       string
+		f          CaseFilter
+		apiFilter  testcase.Filter
+		useSuites  bool
+		useCases   bool
+		casesErr   error
+		want       string
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:      "nested suites",
+			f:         CaseFilter{SuiteID: 2},
+			useSuites: true,
+			useCases:  true,
+			want:      "(Qase ID: 11|13)",
+		},
+		{
+			name:     "tags",
+			f:        CaseFilter{Tags: []string{"smoke"}},
+			useCases: true,
+			want:     "(Qase ID: 10|11|12)",
+		},
+		{
+			name:      "suite and tags",
+			f:         CaseFilter{SuiteID: 1, Tags: []string{"smoke"}},
+			useSuites: true,
+			useCases:  true,
+			want:      "(Qase ID: 10|11)",
+		},
+		{
+			name: "api filters",
+			f: CaseFilter{
+				Priority:   []string{"high"},
+				Severity:   []string{"critical", "major"},
+				Automation: "manual",
+				Query:      "login",
+			},
+			apiFilter: testcase.Filter{
+				Search:     "login",
+				Severity:   "critical,major",
+				Priority:   "high",
+				Automation: "is-not-automated",
+			},
+			useCases: true,
+			want:     "(Qase ID: 10|11|12|13)",
+		},
+		{
+			name:       "unknown automation status",
+			f:          CaseFilter{Automation: "sometimes"},
+			wantErr:    true,
+			errMessage: "unknown automation status \"sometimes\", allowed statuses: automated, manual, to-be-automated",
+		},
+		{
+			name:       "suite not found",
+			f:          CaseFilter{SuiteID: 9},
+			useSuites:  true,
+			wantErr:    true,
+			errMessage: "suite 9 not found",
+		},
+		{
+			name:       "no cases found",
+			f:          CaseFilter{Tags: []string{"regression"}},
+			useCases:   true,
+			wantErr:    true,
+			errMessage: "no cases found matching the filters",
+		},
+		{
+			name:       "failed to get cases",
+			f:          CaseFilter{Query: "login"},
+			apiFilter:  testcase.Filter{Search: "login"},
+			useCases:   true,
+			casesErr:   errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get test cases: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.useSuites {
+				f.client.EXPECT().GetSuites(gomock.Any(), "test").Return(suites, nil)
+			}
+			if tt.useCases {
+				f.client.EXPECT().GetCases(gomock.Any(), "test", tt.apiFilter).Return(cases, tt.casesErr)
+			}
+
+			s := NewService(f.client)
+
+			got, err := s.GetFilter(context.Background(), "test", Source{Cases: tt.f}, "playwright", 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.GetFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Fatalf("Service.GetFilter() error = %v, wantErr %v", err, tt.errMessage)
+			}
+			if err == nil && got.Filters[0] != tt.want {
+				t.Errorf("Service.GetFilter() = %v, want %v", got.Filters[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetFilter_Sources(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        Source
+		errMessage string
+	}{
+		{
+			name:       "no source",
+			src:        Source{},
+			errMessage: "plan ID, run ID or case filters are required",
+		},
+		{
+			name:       "several sources",
+			src:        Source{PlanID: 1, Cases: CaseFilter{Query: "login"}},
+			errMessage: "only one of plan ID, run ID and case filters can be used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(newFixture(t).client)
+
+			_, err := s.GetFilter(context.Background(), "test", tt.src, "playwright", 0)
+			if err == nil || err.Error() != tt.errMessage {
+				t.Errorf("Service.GetFilter() error = %v, want %v", err, tt.errMessage)
+			}
+		})
+	}
+}
@@ -4,13 +4,29 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/qase-tms/qasectl/internal/models/plan"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"github.com/qase-tms/qasectl/internal/models/testcase"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type client interface {
 	GetPlan(ctx context.Context, projectCode string, planID int64) (plan.PlanDetailed, error)
+	GetRunResults(ctx context.Context, projectCode string, runID int64) ([]run.Result, error)
+	GetCases(ctx context.Context, projectCode string, f testcase.Filter) ([]testcase.TestCase, error)
+	GetSuites(ctx context.Context, projectCode string) ([]testcase.Suite, error)
+}
+
+// Source selects the test cases to filter by. Exactly one of PlanID, RunID and the case filters has to be set.
+type Source struct {
+	PlanID int64
+	RunID  int64
+	// Statuses selects the cases of the run whose last result has one of these statuses
+	Statuses []string
+	// Cases selects the test cases of the project
+	Cases CaseFilter
 }
 
 type Service struct {
@@ -22,40 +38,88 @@ func NewService(client client) *Service {
 	return &Service{client: client}
 }
 
-// GetFilteredResults returns the filtered results for the given plan ID and framework
-func (s *Service) GetFilteredResults(ctx context.Context, project string, planID int64, framework string) (string, error) {
-	const op = "result.service.getfilteredresults"
+// Filter holds the selected cases and the framework specific filters
+type Filter struct {
+	Framework string  `json:"framework"`
+	Cases     []int64 `json:"cases"`
+	// Filters holds the filter, or several filters when it is split into chunks
+	Filters []string `json:"filters"`
+}
+
+// GetFilter returns the filter for the given source and framework.
+// When maxLength is positive, the cases are split into chunks whose filters are at most maxLength long.
+func (s *Service) GetFilter(ctx context.Context, project string, src Source, framework string, maxLength int) (Filter, error) {
+	const op = "result.service.getfilter"
 	logger := slog.With("op", op)
 
-	logger.Debug("getting filtered results", "project", project, "planID", planID, "framework", framework)
+	logger.Debug("getting filter", "project", project, "source", src, "framework", framework, "maxLength", maxLength)
+
+	var (
+		IDs []int64
+		err error
+	)
+	sources := 0
+	for _, set := range []bool{src.PlanID != 0, src.RunID != 0, !src.Cases.IsEmpty()} {
+		if set {
+			sources++
+		}
+	}
+
+	switch {
+	case sources > 1:
+		return Filter{}, fmt.Errorf("only one of plan ID, run ID and case filters can be used")
+	case src.PlanID != 0:
+		IDs, err = s.getPlanCases(ctx, project, src.PlanID)
+	case src.RunID != 0:
+		IDs, err = s.getRunCases(ctx, project, src.RunID, src.Statuses)
+	case !src.Cases.IsEmpty():
+		IDs, err = s.getCases(ctx, project, src.Cases)
+	default:
+		return Filter{}, fmt.Errorf("plan ID, run ID or case filters are required")
+	}
+	if err != nil {
+		return Filter{}, err
+	}
+
+	filters, err := generate(framework, IDs, maxLength)
+	if err != nil {
+		return Filter{}, err
+	}
 
+	logger.Debug("prepared filter", "cases", len(IDs), "chunks", len(filters))
+
+	return Filter{Framework: framework, Cases: IDs, Filters: filters}, nil
+}
+
+// getPlanCases returns the IDs of the cases in the plan
+func (s *Service) getPlanCases(ctx context.Context, project string, planID int64) ([]int64, error) {
 	plan, err := s.client.GetPlan(ctx, project, planID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if len(plan.Cases) == 0 {
-		return "", fmt.Errorf("no cases found in plan")
+		return nil, fmt.Errorf("no cases found in plan")
 	}
 
-	switch framework {
-	case "playwright":
-		return prepareForPlaywright(plan.Cases), nil
-	default:
-		return "", fmt.Errorf("unsupported framework: %s", framework)
-	}
+	return plan.Cases, nil
 }
 
-// prepareForPlaywright prepares the IDs for playwright. Create regex pattern: "(Qase ID: 1|2|3|...)"
-func prepareForPlaywright(IDs []int64) string {
-	pattern := "(Qase ID: "
-	for i, caseID := range IDs {
-		if i > 0 {
-			pattern += "|"
-		}
-		pattern += fmt.Sprintf("%d", caseID)
+// getRunCases returns the sorted IDs of the cases in the run whose last result has one of the statuses
+func (s *Service) getRunCases(ctx context.Context, project string, runID int64, statuses []string) ([]int64, error) {
+	if len(statuses) == 0 {
+		statuses = run.DefaultSelectStatuses
+	}
+
+	results, err := s.client.GetRunResults(ctx, project, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results of run %d: %w", runID, err)
+	}
+
+	IDs := run.SelectCases(results, statuses)
+	if len(IDs) == 0 {
+		return nil, fmt.Errorf("no cases with statuses %s found in run %d", strings.Join(statuses, ", "), runID)
 	}
-	pattern += ")"
 
-	return pattern
+	return IDs, nil
 }
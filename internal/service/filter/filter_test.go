@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/qase-tms/qasectl/internal/models/plan"
+	"github.com/qase-tms/qasectl/internal/models/run"
 	"go.uber.org/mock/gomock"
 )
 
-func TestService_GetFilteredResults(t *testing.T) {
+func TestService_GetFilter_Plan(t *testing.T) {
 	type args struct {
 		project   string
 		planID    int64
@@ -127,53 +129,89 @@ func TestService_GetFilteredResults(t *testing.T) {
 
 			s := NewService(f.client)
 
-			got, err := s.GetFilteredResults(context.Background(), tt.args.project, tt.args.planID, tt.args.framework)
+			got, err := s.GetFilter(context.Background(), tt.args.project, Source{PlanID: tt.args.planID}, tt.args.framework, 0)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Service.GetFilteredResults() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Service.GetFilter() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil && err.Error() != tt.errMessage {
-				t.Errorf("Service.GetFilteredResults() error = %v, wantErr %v", err, tt.errMessage)
+				t.Errorf("Service.GetFilter() error = %v, wantErr %v", err, tt.errMessage)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("Service.GetFilteredResults() = %v, want %v", got, tt.want)
+			if err == nil && got.Filters[0] != tt.want {
+				t.Errorf("Service.GetFilter() = %v, want %v", got.Filters[0], tt.want)
 			}
 		})
 	}
 }
 
-func TestPrepareForPlaywright(t *testing.T) {
+func TestService_GetFilter_Run(t *testing.T) {
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	retry := first.Add(time.Minute)
+
+	results := []run.Result{
+		{CaseID: 5, Status: "failed"},
+		{CaseID: 3, Status: "invalid"},
+		{CaseID: 8, Status: "blocked"},
+		{CaseID: 1, Status: "passed"},
+		{CaseID: 7, Status: "failed", EndTime: &first},
+		{CaseID: 7, Status: "passed", EndTime: &retry},
+		{CaseID: 9, Status: "passed", EndTime: &first},
+		{CaseID: 9, Status: "failed", EndTime: &retry},
+		{Status: "failed"},
+	}
+
 	tests := []struct {
-		name string
-		IDs  []int64
-		want string
+		name       string
+		statuses   []string
+		results    []run.Result
+		err        error
+		want       string
+		wantErr    bool
+		errMessage string
 	}{
 		{
-			name: "single ID",
-			IDs:  []int64{123},
-			want: "(Qase ID: 123)",
+			name:    "default statuses",
+			results: results,
+			want:    "(Qase ID: 3|5|8|9)",
 		},
 		{
-			name: "multiple IDs",
-			IDs:  []int64{123, 456, 789},
-			want: "(Qase ID: 123|456|789)",
+			name:     "failed only",
+			statuses: []string{"Failed"},
+			results:  results,
+			want:     "(Qase ID: 5|9)",
 		},
 		{
-			name: "empty IDs",
-			IDs:  []int64{},
-			want: "(Qase ID: )",
+			name:       "no matching cases",
+			statuses:   []string{"skipped"},
+			results:    results,
+			wantErr:    true,
+			errMessage: "no cases with statuses skipped found in run 10",
 		},
 		{
-			name: "large numbers",
-			IDs:  []int64{999999, 1000000},
-			want: "(Qase ID: 999999|1000000)",
+			name:       "failed to get results",
+			err:        errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get results of run 10: error",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := prepareForPlaywright(tt.IDs); got != tt.want {
-				t.Errorf("prepareForPlaywright() = %v, want %v", got, tt.want)
+			f := newFixture(t)
+
+			f.client.EXPECT().GetRunResults(gomock.Any(), "test", int64(10)).Return(tt.results, tt.err)
+
+			s := NewService(f.client)
+
+			got, err := s.GetFilter(context.Background(), "test", Source{RunID: 10, Statuses: tt.statuses}, "playwright", 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.GetFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Fatalf("Service.GetFilter() error = %v, wantErr %v", err, tt.errMessage)
+			}
+			if err == nil && got.Filters[0] != tt.want {
+				t.Errorf("Service.GetFilter() = %v, want %v", got.Filters[0], tt.want)
 			}
 		})
 	}
@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// patterns describe the framework specific filters built from the case IDs.
+// Tests are matched by the Qase ID in their name, see docs/command.md for the naming conventions.
+var patterns = map[string]pattern{
+	// a regex for --grep: "(Qase ID: 1|2|3|...)"
+	"playwright": {prefix: "(Qase ID: ", item: "%d", sep: "|", suffix: ")"},
+	// a -m marker expression matching the qase_id(id=N) marker set by @qase.id(N) of the Qase pytest reporter,
+	// it needs pytest 8.3 or later. Marker arguments are compared as values, so qase_id(id=1) does not select
+	// the tests with the ID 12: "qase_id(id=1) or qase_id(id=2) or ..."
+	"pytest": {item: "qase_id(id=%d)", sep: " or "},
+	// a --testNamePattern regex: "\(Qase ID: (1|2|...)\)"
+	"jest":   {prefix: `\(Qase ID: (`, item: "%d", sep: "|", suffix: `)\)`},
+	"vitest": {prefix: `\(Qase ID: (`, item: "%d", sep: "|", suffix: `)\)`},
+	// a grep value for @cypress/grep: "(Qase ID: 1);(Qase ID: 2);..."
+	"cypress": {item: "(Qase ID: %d)", sep: ";"},
+	// a -Dtest value for Maven Surefire: "*#*QaseId1,*#*QaseId2,..."
+	"junit5": {item: "*#*QaseId%d", sep: ","},
+	"maven":  {item: "*#*QaseId%d", sep: ","},
+	// --tests arguments: "--tests *QaseId1 --tests *QaseId2 ..."
+	"gradle": {item: "--tests *QaseId%d", sep: " "},
+	// a TestNG XML suite running the methods whose names end with QaseId<ID>
+	"testng": {prefix: testNGHeader + `          method.getName().matches(".*QaseId(`, item: "%d", sep: "|", suffix: `)")` + "\n" + testNGFooter},
+	// a -run regex: "QaseID(1|2|...)$"
+	"go": {prefix: "QaseID(", item: "%d", sep: "|", suffix: ")$"},
+	// --test arguments, robot ignores spaces in the patterns: "--test *(QaseID:1) --test *(QaseID:2) ..."
+	"robot": {item: "--test *(QaseID:%d)", sep: " "},
+}
+
+const testNGHeader = `<!DOCTYPE suite SYSTEM "https://testng.org/testng-1.0.dtd">
+<suite name="Qase">
+  <test name="Qase">
+    <method-selectors>
+      <method-selector>
+        <script language="beanshell"><![CDATA[
+`
+
+const testNGFooter = `        ]]></script>
+      </method-selector>
+    </method-selectors>
+    <packages>
+      <package name=".*"/>
+    </packages>
+  </test>
+</suite>`
+
+// pattern is a filter made of the prefix, every ID formatted with item and joined with sep, and the suffix
+type pattern struct {
+	prefix, item, sep, suffix string
+}
+
+// build returns the filter of the IDs
+func (p pattern) build(IDs []int64) string {
+	return p.prefix + join(IDs, p.item, p.sep) + p.suffix
+}
+
+// Frameworks returns the names of the supported frameworks
+func Frameworks() []string {
+	return slices.Sorted(maps.Keys(patterns))
+}
+
+// generate returns the filters for the framework. When maxLength is positive, the IDs are split into
+// chunks whose filters are at most maxLength long, except for chunks of a single ID.
+func generate(framework string, IDs []int64, maxLength int) ([]string, error) {
+	p, ok := patterns[framework]
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework: %s", framework)
+	}
+
+	if maxLength <= 0 {
+		return []string{p.build(IDs)}, nil
+	}
+
+	// the chunk length is tracked by the length of every ID fragment, so each filter is built once
+	filters := make([]string, 0)
+	start, length := 0, len(p.prefix)+len(p.suffix)
+	for i, id := range IDs {
+		fragment := len(fmt.Sprintf(p.item, id))
+		if i > start {
+			fragment += len(p.sep)
+		}
+
+		if i > start && length+fragment > maxLength {
+			filters = append(filters, p.build(IDs[start:i]))
+			start, length = i, len(p.prefix)+len(p.suffix)
+			fragment -= len(p.sep)
+		}
+		length += fragment
+	}
+	filters = append(filters, p.build(IDs[start:]))
+
+	return filters, nil
+}
+
+// join formats every ID with format and joins the results with sep
+func join(IDs []int64, format, sep string) string {
+	parts := make([]string, 0, len(IDs))
+	for _, id := range IDs {
+		parts = append(parts, fmt.Sprintf(format, id))
+	}
+
+	return strings.Join(parts, sep)
+}
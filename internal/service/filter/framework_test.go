@@ -0,0 +1,184 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPattern_Playwright(t *testing.T) {
+	tests := []struct {
+		name string
+		IDs  []int64
+		want string
+	}{
+		{
+			name: "single ID",
+			IDs:  []int64{123},
+			want: "(Qase ID: 123)",
+		},
+		{
+			name: "multiple IDs",
+			IDs:  []int64{123, 456, 789},
+			want: "(Qase ID: 123|456|789)",
+		},
+		{
+			name: "empty IDs",
+			IDs:  []int64{},
+			want: "(Qase ID: )",
+		},
+		{
+			name: "large numbers",
+			IDs:  []int64{999999, 1000000},
+			want: "(Qase ID: 999999|1000000)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := patterns["playwright"].build(tt.IDs); got != tt.want {
+				t.Errorf("build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	IDs := []int64{1, 23}
+
+	tests := []struct {
+		framework string
+		want      string
+	}{
+		{framework: "pytest", want: "qase_id(id=1) or qase_id(id=23)"},
+		{framework: "jest", want: `\(Qase ID: (1|23)\)`},
+		{framework: "vitest", want: `\(Qase ID: (1|23)\)`},
+		{framework: "cypress", want: "(Qase ID: 1);(Qase ID: 23)"},
+		{framework: "junit5", want: "*#*QaseId1,*#*QaseId23"},
+		{framework: "maven", want: "*#*QaseId1,*#*QaseId23"},
+		{framework: "gradle", want: "--tests *QaseId1 --tests *QaseId23"},
+		{framework: "go", want: "QaseID(1|23)$"},
+		{framework: "robot", want: "--test *(QaseID:1) --test *(QaseID:23)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			if got := patterns[tt.framework].build(IDs); got != tt.want {
+				t.Errorf("pattern %s = %v, want %v", tt.framework, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPattern_Pytest(t *testing.T) {
+	// the filter is passed as pytest -m "$FILTER" and matches the marker arguments of @qase.id(N) exactly,
+	// so the test with the ID 12 is not selected by qase_id(id=1)
+	got := fmt.Sprintf("pytest -m %q", patterns["pytest"].build([]int64{1, 12}))
+	want := `pytest -m "qase_id(id=1) or qase_id(id=12)"`
+	if got != want {
+		t.Errorf("command line = %v, want %v", got, want)
+	}
+}
+
+func TestPattern_TestNG(t *testing.T) {
+	got := patterns["testng"].build([]int64{1, 23})
+
+	for _, want := range []string{
+		`<suite name="Qase">`,
+		`method.getName().matches(".*QaseId(1|23)")`,
+		`<package name=".*"/>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("build() = %v, want to contain %v", got, want)
+		}
+	}
+}
+
+func TestFrameworks(t *testing.T) {
+	got := strings.Join(Frameworks(), ",")
+	want := "cypress,go,gradle,jest,junit5,maven,playwright,pytest,robot,testng,vitest"
+	if got != want {
+		t.Errorf("Frameworks() = %v, want %v", got, want)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		framework string
+		IDs       []int64
+		maxLength int
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "no chunks",
+			framework: "go",
+			IDs:       []int64{1, 2, 3},
+			want:      []string{"QaseID(1|2|3)$"},
+		},
+		{
+			name:      "fits max length",
+			framework: "go",
+			IDs:       []int64{1, 2, 3},
+			maxLength: 14,
+			want:      []string{"QaseID(1|2|3)$"},
+		},
+		{
+			name:      "chunks",
+			framework: "go",
+			IDs:       []int64{1, 2, 3, 456789},
+			maxLength: 12,
+			want:      []string{"QaseID(1|2)$", "QaseID(3)$", "QaseID(456789)$"},
+		},
+		{
+			name:      "unsupported framework",
+			framework: "mocha",
+			IDs:       []int64{1},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generate(tt.framework, tt.IDs, tt.maxLength)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_ChunkLength(t *testing.T) {
+	IDs := make([]int64, 0, 500)
+	for id := int64(1); id <= 500; id++ {
+		IDs = append(IDs, id)
+	}
+
+	for _, framework := range Frameworks() {
+		t.Run(framework, func(t *testing.T) {
+			p := patterns[framework]
+			maxLength := len(p.build(IDs[:1])) + 40
+
+			// every chunk is grown while the next ID fits
+			want := make([]string, 0)
+			for start := 0; start < len(IDs); {
+				end := start + 1
+				for end < len(IDs) && len(p.build(IDs[start:end+1])) <= maxLength {
+					end++
+				}
+				want = append(want, p.build(IDs[start:end]))
+				start = end
+			}
+
+			got, err := generate(framework, IDs, maxLength)
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("generate() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	reflect "reflect"
 
 	plan "github.com/qase-tms/qasectl/internal/models/plan"
+	run "github.com/qase-tms/qasectl/internal/models/run"
+	testcase "github.com/qase-tms/qasectl/internal/models/testcase"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,21 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 	return m.recorder
 }
 
+// GetCases mocks base method.
+func (m *Mockclient) GetCases(ctx context.Context, projectCode string, f testcase.Filter) ([]testcase.TestCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCases", ctx, projectCode, f)
+	ret0, _ := ret[0].([]testcase.TestCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCases indicates an expected call of GetCases.
+func (mr *MockclientMockRecorder) GetCases(ctx, projectCode, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCases", reflect.TypeOf((*Mockclient)(nil).GetCases), ctx, projectCode, f)
+}
+
 // GetPlan mocks base method.
 func (m *Mockclient) GetPlan(ctx context.Context, projectCode string, planID int64) (plan.PlanDetailed, error) {
 	m.ctrl.T.Helper()
@@ -54,3 +71,33 @@ func (mr *MockclientMockRecorder) GetPlan(ctx, projectCode, planID any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*Mockclient)(nil).GetPlan), ctx, projectCode, planID)
 }
+
+// GetRunResults mocks base method.
+func (m *Mockclient) GetRunResults(ctx context.Context, projectCode string, runID int64) ([]run.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunResults", ctx, projectCode, runID)
+	ret0, _ := ret[0].([]run.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunResults indicates an expected call of GetRunResults.
+func (mr *MockclientMockRecorder) GetRunResults(ctx, projectCode, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunResults", reflect.TypeOf((*Mockclient)(nil).GetRunResults), ctx, projectCode, runID)
+}
+
+// GetSuites mocks base method.
+func (m *Mockclient) GetSuites(ctx context.Context, projectCode string) ([]testcase.Suite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuites", ctx, projectCode)
+	ret0, _ := ret[0].([]testcase.Suite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuites indicates an expected call of GetSuites.
+func (mr *MockclientMockRecorder) GetSuites(ctx, projectCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuites", reflect.TypeOf((*Mockclient)(nil).GetSuites), ctx, projectCode)
+}
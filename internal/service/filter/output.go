@@ -0,0 +1,137 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatDotenv       = "dotenv"
+	FormatShell        = "shell"
+	FormatGithubOutput = "github-output"
+	FormatJSON         = "json"
+	FormatPlain        = "plain"
+	FormatFileList     = "file-list"
+)
+
+// OutputFormats are the supported output formats of the filter
+var OutputFormats = []string{FormatDotenv, FormatShell, FormatGithubOutput, FormatJSON, FormatPlain, FormatFileList}
+
+// Variable is the name of the variable holding the filter in the dotenv, shell and github-output formats
+const Variable = "QASE_FILTERED_RESULTS"
+
+// shellMetacharacters are the characters a shell splits or expands unquoted values on
+const shellMetacharacters = " \t|&;()<>*?[]{}$`\"\\#~!"
+
+// githubDelimiter ends the multiline values in the github-output format
+const githubDelimiter = "QASE_FILTER_EOF"
+
+// Write writes the filter to w in the given format:
+//   - dotenv: QASE_FILTERED_RESULTS=<filter>, the variables are separated by new lines and multiline filters are quoted
+//   - shell: the dotenv variables quoted to be sourced by a shell
+//   - github-output: the same variables in the GitHub Actions output syntax
+//   - json: the filter as JSON
+//   - plain: the filters, one per line
+//   - file-list: the case IDs, one per line
+//
+// A filter split into chunks is written to the variables QASE_FILTERED_RESULTS_1..N and
+// QASE_FILTERED_RESULTS_COUNT holds the number of chunks.
+func Write(w io.Writer, format string, f Filter) error {
+	var b strings.Builder
+
+	switch format {
+	case FormatDotenv:
+		// the lines are written without a trailing new line, as a single filter has always been written
+		lines := make([]string, 0, len(f.Filters)+1)
+		for _, v := range variables(f.Filters) {
+			lines = append(lines, dotenvLine(v[0], v[1]))
+		}
+		b.WriteString(strings.Join(lines, "\n"))
+	case FormatShell:
+		for _, v := range variables(f.Filters) {
+			b.WriteString(shellLine(v[0], v[1]) + "\n")
+		}
+	case FormatGithubOutput:
+		for _, v := range variables(f.Filters) {
+			b.WriteString(githubLine(v[0], v[1]))
+		}
+	case FormatJSON:
+		data, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal filter: %w", err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	case FormatPlain:
+		for _, filter := range f.Filters {
+			b.WriteString(filter + "\n")
+		}
+	case FormatFileList:
+		for _, id := range f.Cases {
+			fmt.Fprintf(&b, "%d\n", id)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q, allowed formats: %s", format, strings.Join(OutputFormats, ", "))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write filter: %w", err)
+	}
+
+	return nil
+}
+
+// variables returns the names and values of the variables holding the filters
+func variables(filters []string) [][2]string {
+	if len(filters) == 1 {
+		return [][2]string{{Variable, filters[0]}}
+	}
+
+	vars := make([][2]string, 0, len(filters)+1)
+	vars = append(vars, [2]string{Variable + "_COUNT", fmt.Sprintf("%d", len(filters))})
+	for i, filter := range filters {
+		vars = append(vars, [2]string{fmt.Sprintf("%s_%d", Variable, i+1), filter})
+	}
+
+	return vars
+}
+
+// dotenvLine returns the dotenv line of the variable without the line break. Multiline values are double quoted.
+func dotenvLine(name, value string) string {
+	if !strings.Contains(value, "\n") {
+		return fmt.Sprintf("%s=%s", name, value)
+	}
+
+	return doubleQuoted(name, value)
+}
+
+// shellLine returns the line of the variable to be sourced by a shell without the line break.
+// Values with spaces or shell metacharacters are single quoted, so the shell neither splits nor expands them.
+// Multiline values and values with single quotes are double quoted.
+func shellLine(name, value string) string {
+	switch {
+	case strings.ContainsAny(value, "\n'"):
+		return doubleQuoted(name, value)
+	case strings.ContainsAny(value, shellMetacharacters):
+		return fmt.Sprintf("%s='%s'", name, value)
+	default:
+		return fmt.Sprintf("%s=%s", name, value)
+	}
+}
+
+// doubleQuoted returns the variable with the value double quoted and its new lines escaped
+func doubleQuoted(name, value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return fmt.Sprintf("%s=\"%s\"", name, r.Replace(value))
+}
+
+// githubLine returns the GitHub Actions output line of the variable, using a delimiter for multiline values
+func githubLine(name, value string) string {
+	if !strings.Contains(value, "\n") {
+		return fmt.Sprintf("%s=%s\n", name, value)
+	}
+
+	return fmt.Sprintf("%s<<%s\n%s\n%s\n", name, githubDelimiter, value, githubDelimiter)
+}
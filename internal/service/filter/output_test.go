@@ -0,0 +1,122 @@
+package filter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	single := Filter{Framework: "playwright", Cases: []int64{1, 2}, Filters: []string{"(Qase ID: 1|2)"}}
+	chunked := Filter{Framework: "playwright", Cases: []int64{1, 2}, Filters: []string{"(Qase ID: 1)", "(Qase ID: 2)"}}
+	multiline := Filter{Framework: "testng", Cases: []int64{1}, Filters: []string{"<suite name=\"Qase\">\n</suite>"}}
+
+	tests := []struct {
+		name       string
+		format     string
+		filter     Filter
+		want       string
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:   "dotenv",
+			format: FormatDotenv,
+			filter: single,
+			want:   "QASE_FILTERED_RESULTS=(Qase ID: 1|2)",
+		},
+		{
+			name:   "dotenv chunked",
+			format: FormatDotenv,
+			filter: chunked,
+			want:   "QASE_FILTERED_RESULTS_COUNT=2\nQASE_FILTERED_RESULTS_1=(Qase ID: 1)\nQASE_FILTERED_RESULTS_2=(Qase ID: 2)",
+		},
+		{
+			name:   "dotenv multiline",
+			format: FormatDotenv,
+			filter: multiline,
+			want:   "QASE_FILTERED_RESULTS=\"<suite name=\\\"Qase\\\">\\n</suite>\"",
+		},
+		{
+			name:   "shell",
+			format: FormatShell,
+			filter: single,
+			want:   "QASE_FILTERED_RESULTS='(Qase ID: 1|2)'\n",
+		},
+		{
+			name:   "shell chunked",
+			format: FormatShell,
+			filter: chunked,
+			want:   "QASE_FILTERED_RESULTS_COUNT=2\nQASE_FILTERED_RESULTS_1='(Qase ID: 1)'\nQASE_FILTERED_RESULTS_2='(Qase ID: 2)'\n",
+		},
+		{
+			name:   "shell plain value",
+			format: FormatShell,
+			filter: Filter{Framework: "junit5", Cases: []int64{1}, Filters: []string{"QaseId1"}},
+			want:   "QASE_FILTERED_RESULTS=QaseId1\n",
+		},
+		{
+			name:   "shell value with a single quote",
+			format: FormatShell,
+			filter: Filter{Framework: "go", Cases: []int64{1}, Filters: []string{"it's $HOME"}},
+			want:   "QASE_FILTERED_RESULTS=\"it's $HOME\"\n",
+		},
+		{
+			name:   "shell multiline",
+			format: FormatShell,
+			filter: multiline,
+			want:   "QASE_FILTERED_RESULTS=\"<suite name=\\\"Qase\\\">\\n</suite>\"\n",
+		},
+		{
+			name:   "github output",
+			format: FormatGithubOutput,
+			filter: single,
+			want:   "QASE_FILTERED_RESULTS=(Qase ID: 1|2)\n",
+		},
+		{
+			name:   "github output multiline",
+			format: FormatGithubOutput,
+			filter: multiline,
+			want:   "QASE_FILTERED_RESULTS<<QASE_FILTER_EOF\n<suite name=\"Qase\">\n</suite>\nQASE_FILTER_EOF\n",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			filter: single,
+			want:   "{\n  \"framework\": \"playwright\",\n  \"cases\": [\n    1,\n    2\n  ],\n  \"filters\": [\n    \"(Qase ID: 1|2)\"\n  ]\n}\n",
+		},
+		{
+			name:   "plain",
+			format: FormatPlain,
+			filter: chunked,
+			want:   "(Qase ID: 1)\n(Qase ID: 2)\n",
+		},
+		{
+			name:   "file list",
+			format: FormatFileList,
+			filter: chunked,
+			want:   "1\n2\n",
+		},
+		{
+			name:       "unknown format",
+			format:     "xml",
+			filter:     single,
+			wantErr:    true,
+			errMessage: "unknown output format \"xml\", allowed formats: dotenv, shell, github-output, json, plain, file-list",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Write(&b, tt.format, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.errMessage)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,14 +4,24 @@ import (
 	"context"
 	"fmt"
 	"github.com/qase-tms/qasectl/internal/models/run"
+	"slices"
+	"strings"
 )
 
+// StatusCompleted is the status of closed milestones
+const StatusCompleted = "completed"
+
+// Statuses are the statuses of milestones
+var Statuses = []string{"active", StatusCompleted}
+
 // client is a client for env
 //
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type client interface {
 	CreateMilestone(ctx context.Context, projectCode, n, d, s string, t int64) (run.Milestone, error)
 	GetMilestones(ctx context.Context, projectCode, milestoneName string) ([]run.Milestone, error)
+	UpdateMilestone(ctx context.Context, projectCode string, id int64, u run.MilestoneUpdate) error
+	DeleteMilestone(ctx context.Context, projectCode string, id int64) error
 }
 
 // Service is a service for milestones
@@ -26,16 +36,80 @@ func NewService(c client) *Service {
 	}
 }
 
-// CreateMilestone creates a new milestone
-func (srv *Service) CreateMilestone(ctx context.Context, projectCode, n, d, s string, t int64) (run.Milestone, error) {
-	mss, err := srv.client.GetMilestones(ctx, projectCode, n)
+// CreateMilestone creates a new milestone. With ifNotExists, a milestone with the same title is returned instead
+// of creating a duplicate, and the returned bool reports that it already existed.
+func (srv *Service) CreateMilestone(ctx context.Context, projectCode, n, d, s string, t int64, ifNotExists bool) (run.Milestone, bool, error) {
+	if ifNotExists {
+		mss, err := srv.client.GetMilestones(ctx, projectCode, n)
+		if err != nil {
+			return run.Milestone{}, false, fmt.Errorf("failed to get milestones: %w", err)
+		}
+
+		// the search matches parts of titles, so "Release 1" also finds "Release 10"
+		for _, ms := range mss {
+			if strings.EqualFold(ms.Title, n) {
+				return ms, true, nil
+			}
+		}
+	}
+
+	ms, err := srv.client.CreateMilestone(ctx, projectCode, n, d, s, t)
+	if err != nil {
+		return run.Milestone{}, false, err
+	}
+
+	return ms, false, nil
+}
+
+// ListMilestones returns the milestones whose titles contain search and that have one of the statuses.
+// Empty values select all milestones.
+func (srv *Service) ListMilestones(ctx context.Context, projectCode, search string, statuses []string) ([]run.Milestone, error) {
+	for _, status := range statuses {
+		if !slices.Contains(Statuses, status) {
+			return nil, fmt.Errorf("invalid status value: %s. allowed values: %s", status, strings.Join(Statuses, ", "))
+		}
+	}
+
+	mss, err := srv.client.GetMilestones(ctx, projectCode, search)
 	if err != nil {
-		return run.Milestone{}, fmt.Errorf("failed to get milestones: %w", err)
+		return nil, fmt.Errorf("failed to get milestones: %w", err)
 	}
 
-	if len(mss) > 0 {
-		return mss[0], nil
+	if len(statuses) == 0 {
+		return mss, nil
 	}
 
-	return srv.client.CreateMilestone(ctx, projectCode, n, d, s, t)
+	filtered := make([]run.Milestone, 0, len(mss))
+	for _, ms := range mss {
+		if slices.ContainsFunc(statuses, func(status string) bool {
+			return strings.EqualFold(status, ms.Status)
+		}) {
+			filtered = append(filtered, ms)
+		}
+	}
+
+	return filtered, nil
+}
+
+// UpdateMilestone updates the milestone
+func (srv *Service) UpdateMilestone(ctx context.Context, projectCode string, id int64, u run.MilestoneUpdate) error {
+	if u.IsEmpty() {
+		return fmt.Errorf("nothing to update")
+	}
+
+	if u.Status != "" && !slices.Contains(Statuses, u.Status) {
+		return fmt.Errorf("invalid status value: %s. allowed values: %s", u.Status, strings.Join(Statuses, ", "))
+	}
+
+	return srv.client.UpdateMilestone(ctx, projectCode, id, u)
+}
+
+// CloseMilestone marks the milestone as completed
+func (srv *Service) CloseMilestone(ctx context.Context, projectCode string, id int64) error {
+	return srv.client.UpdateMilestone(ctx, projectCode, id, run.MilestoneUpdate{Status: StatusCompleted})
+}
+
+// DeleteMilestone deletes the milestone
+func (srv *Service) DeleteMilestone(ctx context.Context, projectCode string, id int64) error {
+	return srv.client.DeleteMilestone(ctx, projectCode, id)
 }
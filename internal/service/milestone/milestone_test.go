@@ -17,11 +17,13 @@ func TestService_CreateMilestone(t *testing.T) {
 		d           string
 		s           string
 		t           int64
+		ifNotExists bool
 	}
 	tests := []struct {
 		name       string
 		args       args
 		want       run.Milestone
+		existed    bool
 		envs       []run.Milestone
 		wantErr    bool
 		errGet     error
@@ -37,6 +39,7 @@ func TestService_CreateMilestone(t *testing.T) {
 				d:           "description",
 				s:           "status",
 				t:           1,
+				ifNotExists: true,
 			},
 			want: run.Milestone{
 				ID:    1,
@@ -57,11 +60,13 @@ func TestService_CreateMilestone(t *testing.T) {
 				d:           "description",
 				s:           "status",
 				t:           1,
+				ifNotExists: true,
 			},
 			want: run.Milestone{
 				ID:    1,
 				Title: "name",
 			},
+			existed: true,
 			envs: []run.Milestone{
 				{
 					ID:    1,
@@ -86,6 +91,7 @@ func TestService_CreateMilestone(t *testing.T) {
 				d:           "description",
 				s:           "status",
 				t:           1,
+				ifNotExists: true,
 			},
 			want:       run.Milestone{},
 			envs:       []run.Milestone{},
@@ -103,6 +109,7 @@ func TestService_CreateMilestone(t *testing.T) {
 				d:           "description",
 				s:           "status",
 				t:           1,
+				ifNotExists: true,
 			},
 			want:       run.Milestone{},
 			envs:       []run.Milestone{},
@@ -112,18 +119,46 @@ func TestService_CreateMilestone(t *testing.T) {
 			errGet:     nil,
 			errMessage: "error",
 		},
+		{
+			name: "success create milestone when only similar titles exist",
+			args: args{
+				projectCode: "projectCode",
+				n:           "Release 1",
+				ifNotExists: true,
+			},
+			want: run.Milestone{
+				ID:    3,
+				Title: "Release 1",
+			},
+			envs:      []run.Milestone{{ID: 2, Title: "Release 10"}},
+			createUse: true,
+		},
+		{
+			name: "success create milestone without lookup",
+			args: args{
+				projectCode: "projectCode",
+				n:           "name",
+			},
+			want: run.Milestone{
+				ID:    1,
+				Title: "name",
+			},
+			createUse: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := newFixture(t)
 
-			f.client.EXPECT().GetMilestones(gomock.Any(), tt.args.projectCode, tt.args.n).Return(tt.envs, tt.errGet)
+			if tt.args.ifNotExists {
+				f.client.EXPECT().GetMilestones(gomock.Any(), tt.args.projectCode, tt.args.n).Return(tt.envs, tt.errGet)
+			}
 			if tt.createUse {
 				f.client.EXPECT().CreateMilestone(gomock.Any(), tt.args.projectCode, tt.args.n, tt.args.d, tt.args.s, tt.args.t).Return(tt.want, tt.errCreate)
 			}
 
 			srv := NewService(f.client)
-			got, err := srv.CreateMilestone(context.Background(), tt.args.projectCode, tt.args.n, tt.args.d, tt.args.s, tt.args.t)
+			got, existed, err := srv.CreateMilestone(context.Background(), tt.args.projectCode, tt.args.n, tt.args.d, tt.args.s, tt.args.t, tt.args.ifNotExists)
 			if err != nil {
 				if !tt.wantErr {
 					t.Errorf("CreateMilestone() error = %v, wantErr %v", err, tt.wantErr)
@@ -135,6 +170,126 @@ func TestService_CreateMilestone(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CreateMilestone() got = %v, want %v", got, tt.want)
 			}
+			assert.Equal(t, existed, tt.existed)
+		})
+	}
+}
+
+func TestService_ListMilestones(t *testing.T) {
+	mss := []run.Milestone{
+		{ID: 1, Title: "Release 1", Status: "completed"},
+		{ID: 2, Title: "Release 2", Status: "active"},
+	}
+
+	tests := []struct {
+		name       string
+		statuses   []string
+		useGet     bool
+		errGet     error
+		want       []run.Milestone
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:   "all",
+			useGet: true,
+			want:   mss,
+		},
+		{
+			name:     "by status",
+			statuses: []string{"active"},
+			useGet:   true,
+			want:     []run.Milestone{mss[1]},
+		},
+		{
+			name:       "invalid status",
+			statuses:   []string{"closed"},
+			wantErr:    true,
+			errMessage: "invalid status value: closed. allowed values: active, completed",
+		},
+		{
+			name:       "failed get milestones",
+			useGet:     true,
+			errGet:     errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get milestones: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.useGet {
+				f.client.EXPECT().GetMilestones(gomock.Any(), "projectCode", "Release").Return(mss, tt.errGet)
+			}
+
+			srv := NewService(f.client)
+			got, err := srv.ListMilestones(context.Background(), "projectCode", "Release", tt.statuses)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListMilestones() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListMilestones() got = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
+
+func TestService_UpdateMilestone(t *testing.T) {
+	tests := []struct {
+		name       string
+		u          run.MilestoneUpdate
+		useUpdate  bool
+		errMessage string
+	}{
+		{
+			name:      "success update milestone",
+			u:         run.MilestoneUpdate{Title: "Release 2.1", Status: "active"},
+			useUpdate: true,
+		},
+		{
+			name:       "nothing to update",
+			u:          run.MilestoneUpdate{},
+			errMessage: "nothing to update",
+		},
+		{
+			name:       "invalid status",
+			u:          run.MilestoneUpdate{Status: "closed"},
+			errMessage: "invalid status value: closed. allowed values: active, completed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.useUpdate {
+				f.client.EXPECT().UpdateMilestone(gomock.Any(), "projectCode", int64(1), tt.u).Return(nil)
+			}
+
+			srv := NewService(f.client)
+			err := srv.UpdateMilestone(context.Background(), "projectCode", 1, tt.u)
+			if tt.errMessage == "" {
+				if err != nil {
+					t.Errorf("UpdateMilestone() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("UpdateMilestone() error = nil, want %v", tt.errMessage)
+			}
+			assert.Equal(t, err.Error(), tt.errMessage)
+		})
+	}
+}
+
+func TestService_CloseMilestone(t *testing.T) {
+	f := newFixture(t)
+	f.client.EXPECT().UpdateMilestone(gomock.Any(), "projectCode", int64(1), run.MilestoneUpdate{Status: "completed"}).Return(nil)
+
+	srv := NewService(f.client)
+	if err := srv.CloseMilestone(context.Background(), "projectCode", 1); err != nil {
+		t.Errorf("CloseMilestone() error = %v", err)
+	}
+}
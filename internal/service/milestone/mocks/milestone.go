@@ -55,6 +55,20 @@ func (mr *MockclientMockRecorder) CreateMilestone(ctx, projectCode, n, d, s, t a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*Mockclient)(nil).CreateMilestone), ctx, projectCode, n, d, s, t)
 }
 
+// DeleteMilestone mocks base method.
+func (m *Mockclient) DeleteMilestone(ctx context.Context, projectCode string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMilestone", ctx, projectCode, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMilestone indicates an expected call of DeleteMilestone.
+func (mr *MockclientMockRecorder) DeleteMilestone(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMilestone", reflect.TypeOf((*Mockclient)(nil).DeleteMilestone), ctx, projectCode, id)
+}
+
 // GetMilestones mocks base method.
 func (m *Mockclient) GetMilestones(ctx context.Context, projectCode, milestoneName string) ([]run.Milestone, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +83,17 @@ func (mr *MockclientMockRecorder) GetMilestones(ctx, projectCode, milestoneName
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestones", reflect.TypeOf((*Mockclient)(nil).GetMilestones), ctx, projectCode, milestoneName)
 }
+
+// UpdateMilestone mocks base method.
+func (m *Mockclient) UpdateMilestone(ctx context.Context, projectCode string, id int64, u run.MilestoneUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMilestone", ctx, projectCode, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMilestone indicates an expected call of UpdateMilestone.
+func (mr *MockclientMockRecorder) UpdateMilestone(ctx, projectCode, id, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMilestone", reflect.TypeOf((*Mockclient)(nil).UpdateMilestone), ctx, projectCode, id, u)
+}
@@ -0,0 +1,19 @@
+package plan
+
+import (
+	"github.com/qase-tms/qasectl/internal/service/plan/mocks"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type fixture struct {
+	client *mocks.Mockclient
+}
+
+func newFixture(t *testing.T) *fixture {
+	ctrl := gomock.NewController(t)
+
+	return &fixture{
+		client: mocks.NewMockclient(ctrl),
+	}
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: plan.go
+//
+// Generated by this command:
+//
+//	mockgen -source=plan.go -destination=/Users/gda/Documents/github/qase-tms/qasectl/internal/service/plan/mocks/plan.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	plan "github.com/qase-tms/qasectl/internal/models/plan"
+	run "github.com/qase-tms/qasectl/internal/models/run"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockclient is a mock of client interface.
+type Mockclient struct {
+	ctrl     *gomock.Controller
+	recorder *MockclientMockRecorder
+}
+
+// MockclientMockRecorder is the mock recorder for Mockclient.
+type MockclientMockRecorder struct {
+	mock *Mockclient
+}
+
+// NewMockclient creates a new mock instance.
+func NewMockclient(ctrl *gomock.Controller) *Mockclient {
+	mock := &Mockclient{ctrl: ctrl}
+	mock.recorder = &MockclientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockclient) EXPECT() *MockclientMockRecorder {
+	return m.recorder
+}
+
+// CreatePlan mocks base method.
+func (m *Mockclient) CreatePlan(ctx context.Context, projectCode, title, description string, cases []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlan", ctx, projectCode, title, description, cases)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlan indicates an expected call of CreatePlan.
+func (mr *MockclientMockRecorder) CreatePlan(ctx, projectCode, title, description, cases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*Mockclient)(nil).CreatePlan), ctx, projectCode, title, description, cases)
+}
+
+// DeletePlan mocks base method.
+func (m *Mockclient) DeletePlan(ctx context.Context, projectCode string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlan", ctx, projectCode, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlan indicates an expected call of DeletePlan.
+func (mr *MockclientMockRecorder) DeletePlan(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlan", reflect.TypeOf((*Mockclient)(nil).DeletePlan), ctx, projectCode, id)
+}
+
+// GetPlan mocks base method.
+func (m *Mockclient) GetPlan(ctx context.Context, projectCode string, planID int64) (plan.PlanDetailed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", ctx, projectCode, planID)
+	ret0, _ := ret[0].(plan.PlanDetailed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan.
+func (mr *MockclientMockRecorder) GetPlan(ctx, projectCode, planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*Mockclient)(nil).GetPlan), ctx, projectCode, planID)
+}
+
+// GetPlans mocks base method.
+func (m *Mockclient) GetPlans(ctx context.Context, projectCode string) ([]plan.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlans", ctx, projectCode)
+	ret0, _ := ret[0].([]plan.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlans indicates an expected call of GetPlans.
+func (mr *MockclientMockRecorder) GetPlans(ctx, projectCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlans", reflect.TypeOf((*Mockclient)(nil).GetPlans), ctx, projectCode)
+}
+
+// GetRunResults mocks base method.
+func (m *Mockclient) GetRunResults(ctx context.Context, projectCode string, runID int64) ([]run.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunResults", ctx, projectCode, runID)
+	ret0, _ := ret[0].([]run.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunResults indicates an expected call of GetRunResults.
+func (mr *MockclientMockRecorder) GetRunResults(ctx, projectCode, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunResults", reflect.TypeOf((*Mockclient)(nil).GetRunResults), ctx, projectCode, runID)
+}
+
+// UpdatePlan mocks base method.
+func (m *Mockclient) UpdatePlan(ctx context.Context, projectCode string, id int64, u plan.Update) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlan", ctx, projectCode, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlan indicates an expected call of UpdatePlan.
+func (mr *MockclientMockRecorder) UpdatePlan(ctx, projectCode, id, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlan", reflect.TypeOf((*Mockclient)(nil).UpdatePlan), ctx, projectCode, id, u)
+}
@@ -0,0 +1,155 @@
+package plan
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/internal/models/plan"
+	"github.com/qase-tms/qasectl/internal/models/run"
+)
+
+// client is a client for plans
+//
+//go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
+type client interface {
+	GetPlans(ctx context.Context, projectCode string) ([]plan.Plan, error)
+	GetPlan(ctx context.Context, projectCode string, planID int64) (plan.PlanDetailed, error)
+	CreatePlan(ctx context.Context, projectCode, title, description string, cases []int64) (int64, error)
+	UpdatePlan(ctx context.Context, projectCode string, id int64, u plan.Update) error
+	DeletePlan(ctx context.Context, projectCode string, id int64) error
+	GetRunResults(ctx context.Context, projectCode string, runID int64) ([]run.Result, error)
+}
+
+// CreateParams holds the title of a new plan and the sources of its cases.
+// The cases of all the sources are added to the plan.
+type CreateParams struct {
+	Title       string
+	Description string
+	Cases       []int64
+	// FromRunID adds the cases of the run whose last result has one of the Statuses
+	FromRunID int64
+	Statuses  []string
+}
+
+// Service is a service for plans
+type Service struct {
+	client client
+}
+
+// NewService creates a new service for plans
+func NewService(c client) *Service {
+	return &Service{
+		client: c,
+	}
+}
+
+// ListPlans returns the plans of the project
+func (s *Service) ListPlans(ctx context.Context, projectCode string) ([]plan.Plan, error) {
+	plans, err := s.client.GetPlans(ctx, projectCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// GetPlan returns the plan with its cases
+func (s *Service) GetPlan(ctx context.Context, projectCode string, id int64) (plan.PlanDetailed, error) {
+	return s.client.GetPlan(ctx, projectCode, id)
+}
+
+// CreatePlan creates a plan with the cases of the params and returns its ID
+func (s *Service) CreatePlan(ctx context.Context, projectCode string, p CreateParams) (int64, error) {
+	const op = "plan.createplan"
+	logger := slog.With("op", op)
+
+	cases := slices.Clone(p.Cases)
+
+	if p.FromRunID != 0 {
+		statuses := p.Statuses
+		if len(statuses) == 0 {
+			statuses = run.DefaultSelectStatuses
+		}
+
+		results, err := s.client.GetRunResults(ctx, projectCode, p.FromRunID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get results of run %d: %w", p.FromRunID, err)
+		}
+
+		fromRun := run.SelectCases(results, statuses)
+		logger.Debug("selected cases from run", "runID", p.FromRunID, "statuses", statuses, "cases", len(fromRun))
+
+		cases = append(cases, fromRun...)
+	}
+
+	slices.Sort(cases)
+	cases = slices.Compact(cases)
+
+	if len(cases) == 0 {
+		return 0, fmt.Errorf("no cases to add to the plan")
+	}
+
+	return s.client.CreatePlan(ctx, projectCode, p.Title, p.Description, cases)
+}
+
+// UpdatePlan updates the plan. Cases replace the cases of the plan.
+func (s *Service) UpdatePlan(ctx context.Context, projectCode string, id int64, u plan.Update) error {
+	if u.IsEmpty() {
+		return fmt.Errorf("nothing to update")
+	}
+
+	return s.client.UpdatePlan(ctx, projectCode, id, u)
+}
+
+// DeletePlan deletes the plan
+func (s *Service) DeletePlan(ctx context.Context, projectCode string, id int64) error {
+	return s.client.DeletePlan(ctx, projectCode, id)
+}
+
+// ReadCasesFile reads the case IDs from the file, see ReadCases for the format
+func ReadCasesFile(name string) ([]int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cases file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return ReadCases(f)
+}
+
+// ReadCases reads case IDs separated by new lines, commas or spaces, like the file-list output of the filter command.
+// Empty lines and lines starting with # are skipped.
+func ReadCases(r io.Reader) ([]int64, error) {
+	cases := make([]int64, 0)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			id, err := strconv.ParseInt(field, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("invalid case ID %q on line %d", field, line)
+			}
+			cases = append(cases, id)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cases: %w", err)
+	}
+
+	return cases, nil
+}
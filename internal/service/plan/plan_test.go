@@ -0,0 +1,147 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/qase-tms/qasectl/internal/models/plan"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_CreatePlan(t *testing.T) {
+	results := []run.Result{
+		{CaseID: 4, Status: "failed"},
+		{CaseID: 2, Status: "passed"},
+		{CaseID: 3, Status: "blocked"},
+	}
+
+	tests := []struct {
+		name       string
+		p          CreateParams
+		useResults bool
+		resultsErr error
+		cases      []int64
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:  "cases",
+			p:     CreateParams{Title: "Regression", Cases: []int64{3, 1, 3}},
+			cases: []int64{1, 3},
+		},
+		{
+			name:       "from run with default statuses",
+			p:          CreateParams{Title: "Regression", FromRunID: 7, Cases: []int64{1}},
+			useResults: true,
+			cases:      []int64{1, 3, 4},
+		},
+		{
+			name:       "from run with statuses",
+			p:          CreateParams{Title: "Regression", FromRunID: 7, Statuses: []string{"failed", "blocked"}},
+			useResults: true,
+			cases:      []int64{3, 4},
+		},
+		{
+			name:       "no cases",
+			p:          CreateParams{Title: "Regression", FromRunID: 7, Statuses: []string{"skipped"}},
+			useResults: true,
+			wantErr:    true,
+			errMessage: "no cases to add to the plan",
+		},
+		{
+			name:       "failed to get results",
+			p:          CreateParams{Title: "Regression", FromRunID: 7},
+			useResults: true,
+			resultsErr: errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get results of run 7: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.useResults {
+				f.client.EXPECT().GetRunResults(gomock.Any(), "project", int64(7)).Return(results, tt.resultsErr)
+			}
+			if !tt.wantErr {
+				f.client.EXPECT().CreatePlan(gomock.Any(), "project", "Regression", "", tt.cases).Return(int64(9), nil)
+			}
+
+			s := NewService(f.client)
+			got, err := s.CreatePlan(context.Background(), "project", tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreatePlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			assert.Equal(t, got, int64(9))
+		})
+	}
+}
+
+func TestService_UpdatePlan(t *testing.T) {
+	f := newFixture(t)
+	u := plan.Update{Title: "Regression 2.0"}
+	f.client.EXPECT().UpdatePlan(gomock.Any(), "project", int64(9), u).Return(nil)
+
+	s := NewService(f.client)
+	if err := s.UpdatePlan(context.Background(), "project", 9, u); err != nil {
+		t.Errorf("UpdatePlan() error = %v", err)
+	}
+
+	err := s.UpdatePlan(context.Background(), "project", 9, plan.Update{})
+	if err == nil || err.Error() != "nothing to update" {
+		t.Errorf("UpdatePlan() error = %v, want nothing to update", err)
+	}
+}
+
+func TestReadCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       []int64
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:  "one per line",
+			input: "1\n2\n\n3\n",
+			want:  []int64{1, 2, 3},
+		},
+		{
+			name:  "separators and comments",
+			input: "# smoke\n1, 2 3\n\t4\n",
+			want:  []int64{1, 2, 3, 4},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  []int64{},
+		},
+		{
+			name:       "invalid ID",
+			input:      "1\nPRJ-2\n",
+			wantErr:    true,
+			errMessage: "invalid case ID \"PRJ-2\" on line 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCases(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package result
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+// defaultImageQuality is the JPEG quality used when re-encoding downscaled screenshots
+const defaultImageQuality = 85
+
+// truncationNote is appended to truncated text attachments
+const truncationNote = "\n\n[truncated by qasectl: original size %d bytes]\n"
+
+// AttachmentPolicy limits the size of the attachments uploaded with each result. Zero values disable a limit.
+type AttachmentPolicy struct {
+	// MaxFileSize is the maximum size of a single attachment in bytes
+	MaxFileSize int64
+	// MaxResultSize is the maximum size of all attachments of a result, including its steps, in bytes
+	MaxResultSize int64
+	// TruncateText truncates text attachments over a limit instead of skipping them
+	TruncateText bool
+	// CompressTextOver gzips text attachments larger than this size in bytes
+	CompressTextOver int64
+	// MaxImageWidth downscales PNG and JPEG images wider than this number of pixels
+	MaxImageWidth int
+	// ImageQuality is the JPEG quality of downscaled images, defaultImageQuality when zero
+	ImageQuality int
+}
+
+// isEmpty reports whether the policy has no limits
+func (p AttachmentPolicy) isEmpty() bool {
+	return p.MaxFileSize == 0 && p.MaxResultSize == 0 && p.CompressTextOver == 0 && p.MaxImageWidth == 0
+}
+
+// attachmentStats counts the attachments changed by the policy
+type attachmentStats struct {
+	skipped    int
+	truncated  int
+	compressed int
+	downscaled int
+	savedBytes int64
+}
+
+// attachmentProcessor applies an AttachmentPolicy and collects statistics.
+// It is not safe for concurrent use.
+type attachmentProcessor struct {
+	policy AttachmentPolicy
+	stats  attachmentStats
+}
+
+// newAttachmentProcessor returns nil when the policy has no limits
+func newAttachmentProcessor(p AttachmentPolicy) (*attachmentProcessor, error) {
+	if p.isEmpty() {
+		return nil, nil
+	}
+	if p.MaxFileSize < 0 || p.MaxResultSize < 0 || p.CompressTextOver < 0 || p.MaxImageWidth < 0 {
+		return nil, fmt.Errorf("attachment limits must not be negative")
+	}
+	if p.ImageQuality < 0 || p.ImageQuality > 100 {
+		return nil, fmt.Errorf("image quality must be between 1 and 100, got %d", p.ImageQuality)
+	}
+	if p.ImageQuality == 0 {
+		p.ImageQuality = defaultImageQuality
+	}
+
+	return &attachmentProcessor{policy: p}, nil
+}
+
+// getStats returns the collected statistics
+func (ap *attachmentProcessor) getStats() attachmentStats {
+	if ap == nil {
+		return attachmentStats{}
+	}
+	return ap.stats
+}
+
+// processResults applies the policy to the attachments of every result and its steps
+func (ap *attachmentProcessor) processResults(results []models.Result) {
+	if ap == nil {
+		return
+	}
+
+	for i := range results {
+		budget := ap.policy.MaxResultSize
+		results[i].Attachments = ap.processList(results[i].Attachments, &budget)
+		ap.processSteps(results[i].Steps, &budget)
+	}
+}
+
+// processSteps applies the policy to step attachments recursively, sharing the budget of the result
+func (ap *attachmentProcessor) processSteps(steps []models.Step, budget *int64) {
+	for i := range steps {
+		steps[i].Execution.Attachments = ap.processList(steps[i].Execution.Attachments, budget)
+		ap.processSteps(steps[i].Steps, budget)
+	}
+}
+
+// processList applies the policy to the attachments and drops the ones that do not fit
+func (ap *attachmentProcessor) processList(attachments []models.Attachment, budget *int64) []models.Attachment {
+	const op = "result.attachmentprocessor.processlist"
+	logger := slog.With("op", op)
+
+	kept := attachments[:0]
+	for _, a := range attachments {
+		size, err := attachmentSize(a)
+		if err != nil {
+			logger.Warn("failed to get attachment size, uploading as is", "name", a.Name, "error", err)
+			kept = append(kept, a)
+			continue
+		}
+		original := size
+
+		a, size = ap.shrink(a, size)
+
+		limit, limited := ap.limit(*budget)
+		if limited && size > limit {
+			if !ap.policy.TruncateText || !isTextAttachment(a) || isCompressed(a) {
+				logger.Info("attachment exceeds the size limit, skipping", "name", a.Name, "size", size, "limit", limit)
+				ap.stats.skipped++
+				continue
+			}
+
+			truncated, ok := ap.truncate(a, size, limit)
+			if !ok {
+				logger.Info("attachment exceeds the size limit, skipping", "name", a.Name, "size", size, "limit", limit)
+				ap.stats.skipped++
+				continue
+			}
+			a, size = truncated, int64(len(*truncated.Content))
+			ap.stats.truncated++
+		}
+
+		if ap.policy.MaxResultSize > 0 {
+			*budget -= size
+		}
+		ap.stats.savedBytes += original - size
+		kept = append(kept, a)
+	}
+
+	return kept
+}
+
+// limit returns the size the next attachment may take given the remaining budget of the result
+func (ap *attachmentProcessor) limit(budget int64) (int64, bool) {
+	switch {
+	case ap.policy.MaxResultSize > 0 && ap.policy.MaxFileSize > 0:
+		return min(budget, ap.policy.MaxFileSize), true
+	case ap.policy.MaxResultSize > 0:
+		return budget, true
+	case ap.policy.MaxFileSize > 0:
+		return ap.policy.MaxFileSize, true
+	default:
+		return 0, false
+	}
+}
+
+// shrink downscales images and compresses text attachments according to the policy
+func (ap *attachmentProcessor) shrink(a models.Attachment, size int64) (models.Attachment, int64) {
+	const op = "result.attachmentprocessor.shrink"
+	logger := slog.With("op", op)
+
+	format := imageFormat(a)
+	downscale := ap.policy.MaxImageWidth > 0 && format != ""
+	compress := ap.policy.CompressTextOver > 0 && size > ap.policy.CompressTextOver && isTextAttachment(a) && !isCompressed(a)
+	if !downscale && !compress {
+		return a, size
+	}
+
+	data, err := attachmentContent(a)
+	if err != nil {
+		logger.Warn("failed to read attachment", "name", a.Name, "error", err)
+		return a, size
+	}
+
+	if downscale {
+		scaled, err := downscaleImage(data, format, ap.policy.MaxImageWidth, ap.policy.ImageQuality)
+		if err != nil {
+			logger.Warn("failed to downscale image", "name", a.Name, "error", err)
+			return a, size
+		}
+		if scaled == nil || int64(len(scaled)) >= size {
+			return a, size
+		}
+		ap.stats.downscaled++
+		return withContent(a, scaled, a.Name, a.ContentType), int64(len(scaled))
+	}
+
+	compressed, err := gzipBytes(data)
+	if err != nil {
+		logger.Warn("failed to compress attachment", "name", a.Name, "error", err)
+		return a, size
+	}
+	if int64(len(compressed)) >= size {
+		return a, size
+	}
+	ap.stats.compressed++
+	return withContent(a, compressed, attachmentName(a)+".gz", "application/gzip"), int64(len(compressed))
+}
+
+// truncate cuts a text attachment to the limit, leaving room for a note with the original size
+func (ap *attachmentProcessor) truncate(a models.Attachment, size, limit int64) (models.Attachment, bool) {
+	note := fmt.Sprintf(truncationNote, size)
+	keep := limit - int64(len(note))
+	if keep <= 0 {
+		return a, false
+	}
+
+	data, err := attachmentContent(a)
+	if err != nil {
+		return a, false
+	}
+
+	// the cut backs off to a rune boundary, so no UTF-8 character is split
+	for keep > 0 && keep < int64(len(data)) && !utf8.RuneStart(data[keep]) {
+		keep--
+	}
+
+	content := make([]byte, 0, limit)
+	content = append(content, data[:keep]...)
+	content = append(content, note...)
+
+	return withContent(a, content, a.Name, a.ContentType), true
+}
+
+// withContent returns a copy of the attachment holding the content in memory.
+// The client uploads it from a temporary file, so the original file is neither overwritten nor removed.
+func withContent(a models.Attachment, content []byte, name, contentType string) models.Attachment {
+	if name == "" {
+		name = attachmentName(a)
+	}
+	a.Name = name
+	a.ContentType = contentType
+	a.Content = &content
+	a.FilePath = nil
+	return a
+}
+
+// attachmentName returns the attachment name, falling back to the file name
+func attachmentName(a models.Attachment) string {
+	if a.Name == "" && a.FilePath != nil {
+		return filepath.Base(*a.FilePath)
+	}
+	return a.Name
+}
+
+// attachmentSize returns the size of the attachment content in bytes
+func attachmentSize(a models.Attachment) (int64, error) {
+	if a.Content != nil {
+		return int64(len(*a.Content)), nil
+	}
+	if a.FilePath == nil {
+		return 0, nil
+	}
+
+	info, err := os.Stat(*a.FilePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// attachmentContent returns the content of the attachment, reading it from disk when needed
+func attachmentContent(a models.Attachment) ([]byte, error) {
+	if a.Content != nil {
+		return *a.Content, nil
+	}
+	if a.FilePath == nil {
+		return nil, nil
+	}
+	return os.ReadFile(*a.FilePath)
+}
+
+// isCompressed reports whether the attachment is a gzip archive
+func isCompressed(a models.Attachment) bool {
+	return a.ContentType == "application/gzip" || strings.HasSuffix(strings.ToLower(attachmentName(a)), ".gz")
+}
+
+// imageFormat returns "png" or "jpeg" for screenshots that can be downscaled and "" otherwise
+func imageFormat(a models.Attachment) string {
+	switch strings.ToLower(a.ContentType) {
+	case "image/png":
+		return "png"
+	case "image/jpeg", "image/jpg":
+		return "jpeg"
+	}
+
+	names := []string{a.Name}
+	if a.FilePath != nil {
+		names = append(names, *a.FilePath)
+	}
+	for _, name := range names {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext == ".png" {
+			return "png"
+		}
+		if slices.Contains([]string{".jpg", ".jpeg"}, ext) {
+			return "jpeg"
+		}
+	}
+
+	return ""
+}
+
+// gzipBytes compresses the data
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// downscaleImage resizes the image to maxWidth keeping its aspect ratio and re-encodes it.
+// It returns nil when the image is not wider than maxWidth.
+func downscaleImage(data []byte, format string, maxWidth, quality int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	if b.Dx() <= maxWidth {
+		return nil, nil
+	}
+
+	height := max(1, b.Dy()*maxWidth/b.Dx())
+	dst := scaleImage(src, maxWidth, height)
+
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		enc := png.Encoder{CompressionLevel: png.BestCompression}
+		err = enc.Encode(&buf, dst)
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scaleImage resizes the image by averaging the source pixels covered by every destination pixel
+func scaleImage(src image.Image, width, height int) *image.NRGBA {
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/width)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					bl += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n),
+				G: uint8(g / n),
+				B: uint8(bl / n),
+				A: uint8(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,264 @@
+package result
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+func textAttachment(name string, size int) models.Attachment {
+	content := []byte(strings.Repeat("a", size))
+	return models.Attachment{Name: name, ContentType: "text/plain", Content: &content}
+}
+
+func binaryAttachment(name string, size int) models.Attachment {
+	content := bytes.Repeat([]byte{0xff}, size)
+	return models.Attachment{Name: name, ContentType: "video/mp4", Content: &content}
+}
+
+func writePNG(t *testing.T, width, height int) string {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 255})
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "screenshot.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	return path
+}
+
+func TestAttachmentProcessor_processResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      AttachmentPolicy
+		attachments []models.Attachment
+		wantNames   []string
+		wantSizes   []int
+		wantStats   attachmentStats
+	}{
+		{
+			name:        "skip files over the limit",
+			policy:      AttachmentPolicy{MaxFileSize: 100},
+			attachments: []models.Attachment{binaryAttachment("video.mp4", 200), textAttachment("log.txt", 50)},
+			wantNames:   []string{"log.txt"},
+			wantSizes:   []int{50},
+			wantStats:   attachmentStats{skipped: 1},
+		},
+		{
+			name:        "truncate text files over the limit",
+			policy:      AttachmentPolicy{MaxFileSize: 100, TruncateText: true},
+			attachments: []models.Attachment{binaryAttachment("video.mp4", 200), textAttachment("log.txt", 500)},
+			wantNames:   []string{"log.txt"},
+			wantSizes:   []int{100},
+			wantStats:   attachmentStats{skipped: 1, truncated: 1, savedBytes: 400},
+		},
+		{
+			name:        "skip attachments over the result budget",
+			policy:      AttachmentPolicy{MaxResultSize: 150},
+			attachments: []models.Attachment{binaryAttachment("a.bin", 100), binaryAttachment("b.bin", 100), binaryAttachment("c.bin", 50)},
+			wantNames:   []string{"a.bin", "c.bin"},
+			wantSizes:   []int{100, 50},
+			wantStats:   attachmentStats{skipped: 1},
+		},
+		{
+			name:        "compress text over the threshold",
+			policy:      AttachmentPolicy{CompressTextOver: 1000},
+			attachments: []models.Attachment{textAttachment("small.log", 500), textAttachment("big.log", 5000)},
+			wantNames:   []string{"small.log", "big.log.gz"},
+			wantSizes:   []int{500, -1},
+			wantStats:   attachmentStats{compressed: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap, err := newAttachmentProcessor(tt.policy)
+			if err != nil {
+				t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+			}
+
+			results := []models.Result{{Attachments: tt.attachments}}
+			ap.processResults(results)
+
+			got := results[0].Attachments
+			if len(got) != len(tt.wantNames) {
+				t.Fatalf("attachments = %d, want %d", len(got), len(tt.wantNames))
+			}
+			for i, a := range got {
+				if a.Name != tt.wantNames[i] {
+					t.Errorf("attachment %d name = %s, want %s", i, a.Name, tt.wantNames[i])
+				}
+				if tt.wantSizes[i] >= 0 && len(*a.Content) != tt.wantSizes[i] {
+					t.Errorf("attachment %s size = %d, want %d", a.Name, len(*a.Content), tt.wantSizes[i])
+				}
+			}
+
+			stats := ap.getStats()
+			if tt.wantStats.savedBytes == 0 {
+				stats.savedBytes = 0
+			}
+			if stats != tt.wantStats {
+				t.Errorf("stats = %+v, want %+v", stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestAttachmentProcessor_truncateNote(t *testing.T) {
+	ap, err := newAttachmentProcessor(AttachmentPolicy{MaxFileSize: 100, TruncateText: true})
+	if err != nil {
+		t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+	}
+
+	results := []models.Result{{Steps: []models.Step{
+		{Execution: models.StepExecution{Attachments: []models.Attachment{textAttachment("step.log", 1000)}}},
+	}}}
+	ap.processResults(results)
+
+	content := string(*results[0].Steps[0].Execution.Attachments[0].Content)
+	if !strings.HasSuffix(content, "[truncated by qasectl: original size 1000 bytes]\n") {
+		t.Errorf("truncated content does not end with a note: %q", content)
+	}
+}
+
+func TestAttachmentProcessor_truncateRuneBoundary(t *testing.T) {
+	note := fmt.Sprintf(truncationNote, 1000)
+	ap, err := newAttachmentProcessor(AttachmentPolicy{MaxFileSize: int64(len(note)) + 11, TruncateText: true})
+	if err != nil {
+		t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+	}
+
+	// "ж" takes 2 bytes, so the limit falls in the middle of the sixth character
+	content := []byte(strings.Repeat("ж", 500))
+	results := []models.Result{{Attachments: []models.Attachment{{Name: "out.log", ContentType: "text/plain", Content: &content}}}}
+	ap.processResults(results)
+
+	got := string(*results[0].Attachments[0].Content)
+	if !utf8.ValidString(got) {
+		t.Errorf("truncated content is not valid UTF-8: %q", got)
+	}
+	if want := strings.Repeat("ж", 5) + note; got != want {
+		t.Errorf("truncated content = %q, want %q", got, want)
+	}
+}
+
+func TestAttachmentProcessor_compressLarger(t *testing.T) {
+	ap, err := newAttachmentProcessor(AttachmentPolicy{CompressTextOver: 10})
+	if err != nil {
+		t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+	}
+
+	// the gzip header alone outgrows short content
+	content := []byte("0123456789abcdef")
+	results := []models.Result{{Attachments: []models.Attachment{{Name: "out.txt", ContentType: "text/plain", Content: &content}}}}
+	ap.processResults(results)
+
+	a := results[0].Attachments[0]
+	if a.Name != "out.txt" || string(*a.Content) != "0123456789abcdef" {
+		t.Errorf("attachment = %s %q, want the original", a.Name, *a.Content)
+	}
+	if stats := ap.getStats(); stats.compressed != 0 {
+		t.Errorf("compressed = %d, want 0", stats.compressed)
+	}
+}
+
+func TestAttachmentProcessor_compressRoundTrip(t *testing.T) {
+	ap, err := newAttachmentProcessor(AttachmentPolicy{CompressTextOver: 10})
+	if err != nil {
+		t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+	}
+
+	results := []models.Result{{Attachments: []models.Attachment{textAttachment("system-out.txt", 100)}}}
+	ap.processResults(results)
+
+	a := results[0].Attachments[0]
+	if a.ContentType != "application/gzip" {
+		t.Errorf("content type = %s, want application/gzip", a.ContentType)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(*a.Content))
+	if err != nil {
+		t.Fatalf("failed to open gzip: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read gzip: %v", err)
+	}
+	if string(data) != strings.Repeat("a", 100) {
+		t.Error("decompressed content differs from the original")
+	}
+}
+
+func TestAttachmentProcessor_downscale(t *testing.T) {
+	path := writePNG(t, 400, 200)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read image: %v", err)
+	}
+
+	ap, err := newAttachmentProcessor(AttachmentPolicy{MaxImageWidth: 100})
+	if err != nil {
+		t.Fatalf("newAttachmentProcessor() unexpected error: %v", err)
+	}
+
+	results := []models.Result{{Attachments: []models.Attachment{
+		{Name: "screenshot.png", ContentType: "image/png", FilePath: &path},
+	}}}
+	ap.processResults(results)
+
+	a := results[0].Attachments[0]
+	if a.FilePath != nil || a.Content == nil {
+		t.Fatal("downscaled image was not replaced with in-memory content")
+	}
+
+	img, err := png.Decode(bytes.NewReader(*a.Content))
+	if err != nil {
+		t.Fatalf("failed to decode downscaled image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("image size = %dx%d, want 100x50", b.Dx(), b.Dy())
+	}
+	if ap.getStats().downscaled != 1 {
+		t.Errorf("downscaled = %d, want 1", ap.getStats().downscaled)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, original) {
+		t.Error("original image was modified")
+	}
+}
+
+func TestNewAttachmentProcessor(t *testing.T) {
+	ap, err := newAttachmentProcessor(AttachmentPolicy{})
+	if err != nil || ap != nil {
+		t.Errorf("newAttachmentProcessor() = %v, %v, want nil processor for empty policy", ap, err)
+	}
+
+	if _, err := newAttachmentProcessor(AttachmentPolicy{MaxImageWidth: 100, ImageQuality: 101}); err == nil {
+		t.Error("newAttachmentProcessor() expected error for invalid quality")
+	}
+	if _, err := newAttachmentProcessor(AttachmentPolicy{MaxFileSize: -1}); err == nil {
+		t.Error("newAttachmentProcessor() expected error for negative limit")
+	}
+}
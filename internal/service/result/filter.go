@@ -0,0 +1,195 @@
+package result
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+// ResultFilter selects the results to upload.
+// A result is uploaded when it matches every include criterion that is set and none of the exclude criteria.
+type ResultFilter struct {
+	IncludeStatuses   []string
+	ExcludeStatuses   []string
+	IncludeSuites     []string
+	ExcludeSuites     []string
+	IncludeTitle      string
+	ExcludeTitle      string
+	IncludeSignatures []string
+	ExcludeSignatures []string
+	IncludeFields     map[string]string
+	ExcludeFields     map[string]string
+}
+
+// isEmpty reports whether the filter has no criteria
+func (f ResultFilter) isEmpty() bool {
+	return len(f.IncludeStatuses) == 0 && len(f.ExcludeStatuses) == 0 &&
+		len(f.IncludeSuites) == 0 && len(f.ExcludeSuites) == 0 &&
+		f.IncludeTitle == "" && f.ExcludeTitle == "" &&
+		len(f.IncludeSignatures) == 0 && len(f.ExcludeSignatures) == 0 &&
+		len(f.IncludeFields) == 0 && len(f.ExcludeFields) == 0
+}
+
+// resultMatcher is a compiled ResultFilter
+type resultMatcher struct {
+	filter       ResultFilter
+	includeTitle *regexp.Regexp
+	excludeTitle *regexp.Regexp
+}
+
+// newResultMatcher validates the filter and compiles its patterns. It returns nil for an empty filter.
+func newResultMatcher(f ResultFilter) (*resultMatcher, error) {
+	if f.isEmpty() {
+		return nil, nil
+	}
+
+	m := &resultMatcher{filter: f}
+
+	for _, pattern := range append(slices.Clone(f.IncludeSuites), f.ExcludeSuites...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid suite pattern %q: %w", pattern, err)
+		}
+	}
+
+	if f.IncludeTitle != "" {
+		re, err := regexp.Compile(f.IncludeTitle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern %q: %w", f.IncludeTitle, err)
+		}
+		m.includeTitle = re
+	}
+
+	if f.ExcludeTitle != "" {
+		re, err := regexp.Compile(f.ExcludeTitle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern %q: %w", f.ExcludeTitle, err)
+		}
+		m.excludeTitle = re
+	}
+
+	return m, nil
+}
+
+// match reports whether the result should be uploaded
+func (m *resultMatcher) match(r models.Result) bool {
+	if m == nil {
+		return true
+	}
+
+	f := m.filter
+	suite := suitePath(r)
+	signature := ""
+	if r.Signature != nil {
+		signature = *r.Signature
+	}
+
+	if len(f.IncludeStatuses) > 0 && !containsFold(f.IncludeStatuses, r.Execution.Status) {
+		return false
+	}
+	if len(f.IncludeSuites) > 0 && !matchSuite(f.IncludeSuites, suite) {
+		return false
+	}
+	if m.includeTitle != nil && !m.includeTitle.MatchString(r.Title) {
+		return false
+	}
+	if len(f.IncludeSignatures) > 0 && !slices.Contains(f.IncludeSignatures, signature) {
+		return false
+	}
+	if len(f.IncludeFields) > 0 && !matchFields(f.IncludeFields, r.Fields, true) {
+		return false
+	}
+
+	if containsFold(f.ExcludeStatuses, r.Execution.Status) {
+		return false
+	}
+	if matchSuite(f.ExcludeSuites, suite) {
+		return false
+	}
+	if m.excludeTitle != nil && m.excludeTitle.MatchString(r.Title) {
+		return false
+	}
+	if slices.Contains(f.ExcludeSignatures, signature) {
+		return false
+	}
+	if len(f.ExcludeFields) > 0 && matchFields(f.ExcludeFields, r.Fields, false) {
+		return false
+	}
+
+	return true
+}
+
+// filterResults keeps the results accepted by the matcher and returns them with the number of dropped results
+func filterResults(m *resultMatcher, results []models.Result) ([]models.Result, int) {
+	if m == nil {
+		return results, 0
+	}
+
+	filtered := results[:0]
+	for _, r := range results {
+		if m.match(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered, len(results) - len(filtered)
+}
+
+// suitePath joins the suite titles of the result with "/"
+func suitePath(r models.Result) string {
+	titles := make([]string, 0, len(r.Relations.Suite.Data))
+	for _, s := range r.Relations.Suite.Data {
+		if s.Title == "" {
+			continue
+		}
+		titles = append(titles, s.Title)
+	}
+	return strings.Join(titles, "/")
+}
+
+// matchSuite reports whether the suite path or any of its ancestors matches one of the glob patterns
+func matchSuite(patterns []string, suite string) bool {
+	if len(patterns) == 0 || suite == "" {
+		return false
+	}
+
+	parts := strings.Split(suite, "/")
+	for i := len(parts); i > 0; i-- {
+		prefix := strings.Join(parts[:i], "/")
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, prefix); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchFields reports whether the result fields match the expected values.
+// When all is true every expected field must match, otherwise a single match is enough.
+func matchFields(expected, fields map[string]string, all bool) bool {
+	for k, v := range expected {
+		matched := fields[k] == v
+		if all && !matched {
+			return false
+		}
+		if !all && matched {
+			return true
+		}
+	}
+	return all
+}
+
+// containsFold reports whether the list contains the value ignoring case
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
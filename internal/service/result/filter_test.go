@@ -0,0 +1,158 @@
+package result
+
+import (
+	"testing"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+func filterModel(title, status, signature string, suites []string, fields map[string]string) models.Result {
+	data := make([]models.SuiteData, 0, len(suites))
+	for _, s := range suites {
+		data = append(data, models.SuiteData{Title: s})
+	}
+
+	return models.Result{
+		Title:     title,
+		Signature: &signature,
+		Execution: models.Execution{Status: status},
+		Fields:    fields,
+		Relations: models.Relation{Suite: models.Suite{Data: data}},
+	}
+}
+
+func TestResultMatcher_match(t *testing.T) {
+	result := filterModel("Login works", "skipped", "auth::login", []string{"E2E", "Auth", "Login"}, map[string]string{"layer": "e2e"})
+
+	tests := []struct {
+		name   string
+		filter ResultFilter
+		want   bool
+	}{
+		{
+			name:   "empty filter",
+			filter: ResultFilter{},
+			want:   true,
+		},
+		{
+			name:   "exclude status",
+			filter: ResultFilter{ExcludeStatuses: []string{"Skipped"}},
+			want:   false,
+		},
+		{
+			name:   "include other status",
+			filter: ResultFilter{IncludeStatuses: []string{"passed", "failed"}},
+			want:   false,
+		},
+		{
+			name:   "include suite glob matches ancestor",
+			filter: ResultFilter{IncludeSuites: []string{"E2E/*"}},
+			want:   true,
+		},
+		{
+			name:   "include suite glob does not match",
+			filter: ResultFilter{IncludeSuites: []string{"Unit/*"}},
+			want:   false,
+		},
+		{
+			name:   "exclude suite exact path",
+			filter: ResultFilter{ExcludeSuites: []string{"E2E/Auth/Login"}},
+			want:   false,
+		},
+		{
+			name:   "include title regex",
+			filter: ResultFilter{IncludeTitle: "^Login"},
+			want:   true,
+		},
+		{
+			name:   "exclude title regex",
+			filter: ResultFilter{ExcludeTitle: "works$"},
+			want:   false,
+		},
+		{
+			name:   "exclude signature from quarantine list",
+			filter: ResultFilter{ExcludeSignatures: []string{"auth::logout", "auth::login"}},
+			want:   false,
+		},
+		{
+			name:   "include signature",
+			filter: ResultFilter{IncludeSignatures: []string{"auth::logout"}},
+			want:   false,
+		},
+		{
+			name:   "include fields requires all",
+			filter: ResultFilter{IncludeFields: map[string]string{"layer": "e2e", "severity": "critical"}},
+			want:   false,
+		},
+		{
+			name:   "exclude fields matches any",
+			filter: ResultFilter{ExcludeFields: map[string]string{"layer": "e2e", "severity": "critical"}},
+			want:   false,
+		},
+		{
+			name: "include and exclude combined",
+			filter: ResultFilter{
+				IncludeSuites:   []string{"E2E"},
+				IncludeFields:   map[string]string{"layer": "e2e"},
+				ExcludeStatuses: []string{"failed"},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newResultMatcher(tt.filter)
+			if err != nil {
+				t.Fatalf("newResultMatcher() unexpected error: %v", err)
+			}
+
+			if got := m.match(result); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResultMatcher_InvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ResultFilter
+	}{
+		{
+			name:   "invalid title regex",
+			filter: ResultFilter{IncludeTitle: "("},
+		},
+		{
+			name:   "invalid suite glob",
+			filter: ResultFilter{ExcludeSuites: []string{"["}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newResultMatcher(tt.filter); err == nil {
+				t.Error("newResultMatcher() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	results := []models.Result{
+		filterModel("Test 1", "passed", "s1", nil, nil),
+		filterModel("Test 2", "skipped", "s2", nil, nil),
+		filterModel("Test 3", "failed", "s3", nil, nil),
+	}
+
+	m, err := newResultMatcher(ResultFilter{ExcludeStatuses: []string{"skipped"}})
+	if err != nil {
+		t.Fatalf("newResultMatcher() unexpected error: %v", err)
+	}
+
+	filtered, dropped := filterResults(m, results)
+	if dropped != 1 {
+		t.Errorf("filterResults() dropped = %d, want 1", dropped)
+	}
+	if len(filtered) != 2 || filtered[0].Title != "Test 1" || filtered[1].Title != "Test 3" {
+		t.Errorf("filterResults() = %v, want Test 1 and Test 3", filtered)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	result "github.com/qase-tms/qasectl/internal/models/result"
+	run "github.com/qase-tms/qasectl/internal/models/run"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -92,6 +93,20 @@ func (mr *MockParserMockRecorder) Parse() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockParser)(nil).Parse))
 }
 
+// Stream mocks base method.
+func (m *MockParser) Stream(emit func(result.Result) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockParserMockRecorder) Stream(emit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockParser)(nil).Stream), emit)
+}
+
 // MockrunService is a mock of runService interface.
 type MockrunService struct {
 	ctrl     *gomock.Controller
@@ -130,16 +145,47 @@ func (mr *MockrunServiceMockRecorder) CompleteRun(ctx, projectCode, runId any) *
 }
 
 // CreateRun mocks base method.
-func (m_2 *MockrunService) CreateRun(ctx context.Context, p, t, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error) {
+func (m_2 *MockrunService) CreateRun(ctx context.Context, p, t, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error) {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "CreateRun", ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime)
+	ret := m_2.ctrl.Call(m_2, "CreateRun", ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime, customFields, configurations)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRun indicates an expected call of CreateRun.
-func (mr *MockrunServiceMockRecorder) CreateRun(ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime any) *gomock.Call {
+func (mr *MockrunServiceMockRecorder) CreateRun(ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime, customFields, configurations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockrunService)(nil).CreateRun), ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime, customFields, configurations)
+}
+
+// GetRun mocks base method.
+func (m *MockrunService) GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, projectCode, id)
+	ret0, _ := ret[0].(run.Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockrunServiceMockRecorder) GetRun(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockrunService)(nil).GetRun), ctx, projectCode, id)
+}
+
+// ReuseRun mocks base method.
+func (m *MockrunService) ReuseRun(ctx context.Context, projectCode, title, tag string, create func(context.Context) (int64, error)) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReuseRun", ctx, projectCode, title, tag, create)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReuseRun indicates an expected call of ReuseRun.
+func (mr *MockrunServiceMockRecorder) ReuseRun(ctx, projectCode, title, tag, create any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockrunService)(nil).CreateRun), ctx, p, t, d, e, m, plan, tags, isCloud, browser, startTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReuseRun", reflect.TypeOf((*MockrunService)(nil).ReuseRun), ctx, projectCode, title, tag, create)
 }
@@ -1,5 +1,7 @@
 package result
 
+import "github.com/qase-tms/qasectl/internal/tmpl"
+
 type UploadParams struct {
 	RunID                int64
 	Title                string
@@ -10,4 +12,29 @@ type UploadParams struct {
 	Statuses             map[string]string
 	SkipParams           bool
 	AttachmentExtensions string
+	Stream               bool
+	Filter               ResultFilter
+	Rules                []Rule
+	Fields               map[string]string
+	Params               map[string]string
+	Tags                 []string
+	OverrideValues       bool
+	Retries              RetryMode
+	StripTimes           bool
+	RedactSecrets        bool
+	RedactPatterns       []string
+	AttachmentPolicy     AttachmentPolicy
+	SplitBy              string
+	SplitTitle           string
+	TemplateData         *tmpl.Data
+	// CIDescription is appended to the description of the created run after its template is rendered,
+	// so template syntax in CI values like branch names is kept as is
+	CIDescription     string
+	RunTags           []string
+	RunCustomFields   map[string]string
+	RunConfigurations []int64
+	// ReuseRun uploads to an active run with the same title, or with ReuseTag when it is set,
+	// and leaves the run open for the other jobs
+	ReuseRun bool
+	ReuseTag string
 }
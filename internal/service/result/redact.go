@@ -0,0 +1,207 @@
+package result
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+// redactedValue replaces every detected secret
+const redactedValue = "[REDACTED]"
+
+// redactRule replaces the matches of a pattern with a replacement that may reference capture groups
+type redactRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// builtinRedactRules detect common secrets. Prefixes like header names and URL schemes are kept.
+var builtinRedactRules = []redactRule{
+	// JSON Web Tokens
+	{
+		re:          regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+		replacement: redactedValue,
+	},
+	// AWS access key IDs
+	{
+		re:          regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+		replacement: redactedValue,
+	},
+	// AWS secret access keys
+	{
+		re:          regexp.MustCompile(`(?i)(aws_secret_access_key["']?\s*[:=]\s*["']?)[A-Za-z0-9/+=]{40}`),
+		replacement: "${1}" + redactedValue,
+	},
+	// Authorization headers
+	{
+		re:          regexp.MustCompile(`(?i)(\bauthorization["']?\s*[:=]\s*["']?(?:(?:bearer|basic|token)\s+)?)[^\s"',;]+`),
+		replacement: "${1}" + redactedValue,
+	},
+	// bearer tokens outside of headers
+	{
+		re:          regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9\-._~+/]{8,}=*`),
+		replacement: "${1}" + redactedValue,
+	},
+	// credentials in URLs
+	{
+		re:          regexp.MustCompile(`(\b[a-zA-Z][a-zA-Z0-9+.-]*://)[^\s/:@]+:[^\s/@]+@`),
+		replacement: "${1}" + redactedValue + "@",
+	},
+}
+
+// textExtensions are the attachment extensions treated as text when the content type is unknown
+var textExtensions = []string{".txt", ".log", ".json", ".xml", ".yaml", ".yml", ".html", ".csv", ".out", ".err"}
+
+// redactor removes secrets from results before they are uploaded
+type redactor struct {
+	rules []redactRule
+}
+
+// newRedactor compiles the user patterns. It returns nil when redaction is disabled.
+func newRedactor(p UploadParams) (*redactor, error) {
+	if !p.RedactSecrets && len(p.RedactPatterns) == 0 {
+		return nil, nil
+	}
+
+	r := &redactor{}
+	if p.RedactSecrets {
+		r.rules = append(r.rules, builtinRedactRules...)
+	}
+
+	for _, pattern := range p.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, redactRule{re: re, replacement: redactedValue})
+	}
+
+	return r, nil
+}
+
+// redactResults removes secrets from messages, stack traces, params, step comments and text attachments
+func (r *redactor) redactResults(results []models.Result) {
+	if r == nil {
+		return
+	}
+
+	for i := range results {
+		res := &results[i]
+
+		res.Message = r.redactPtr(res.Message)
+		res.Execution.StackTrace = r.redactPtr(res.Execution.StackTrace)
+
+		for k, v := range res.Params {
+			res.Params[k] = r.redact(v)
+		}
+
+		r.redactAttachments(res.Attachments)
+		r.redactSteps(res.Steps)
+	}
+}
+
+// redactSteps redacts step comments and attachments recursively
+func (r *redactor) redactSteps(steps []models.Step) {
+	for i := range steps {
+		steps[i].Execution.Comment = r.redact(steps[i].Execution.Comment)
+		r.redactAttachments(steps[i].Execution.Attachments)
+		r.redactSteps(steps[i].Steps)
+	}
+}
+
+// redactAttachments redacts the content of text attachments.
+// File attachments with secrets are replaced with in-memory content, which is uploaded from a temporary file,
+// the original files are not modified.
+func (r *redactor) redactAttachments(attachments []models.Attachment) {
+	const op = "result.redactor.redactattachments"
+	logger := slog.With("op", op)
+
+	for i := range attachments {
+		a := &attachments[i]
+		if !isTextAttachment(*a) {
+			continue
+		}
+
+		if a.Content != nil {
+			content := r.redactBytes(*a.Content)
+			a.Content = &content
+			continue
+		}
+
+		if a.FilePath == nil {
+			continue
+		}
+
+		data, err := os.ReadFile(*a.FilePath)
+		if err != nil {
+			logger.Warn("failed to read attachment for redaction", "path", *a.FilePath, "error", err)
+			continue
+		}
+
+		content := r.redactBytes(data)
+		if bytes.Equal(content, data) {
+			continue
+		}
+
+		if a.Name == "" {
+			a.Name = filepath.Base(*a.FilePath)
+		}
+		a.Content = &content
+		a.FilePath = nil
+	}
+}
+
+// redact replaces the secrets in the value
+func (r *redactor) redact(v string) string {
+	for _, rule := range r.rules {
+		v = rule.re.ReplaceAllString(v, rule.replacement)
+	}
+	return v
+}
+
+// redactBytes replaces the secrets in the content
+func (r *redactor) redactBytes(v []byte) []byte {
+	for _, rule := range r.rules {
+		v = rule.re.ReplaceAll(v, []byte(rule.replacement))
+	}
+	return v
+}
+
+// redactPtr replaces the secrets in the value if it is set
+func (r *redactor) redactPtr(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	redacted := r.redact(*v)
+	return &redacted
+}
+
+// isTextAttachment reports whether the attachment holds text, judging by its content type or file extension
+func isTextAttachment(a models.Attachment) bool {
+	contentType := strings.ToLower(a.ContentType)
+	if strings.HasPrefix(contentType, "text/") || contentType == "plain/text" ||
+		strings.Contains(contentType, "json") || strings.Contains(contentType, "xml") ||
+		strings.Contains(contentType, "yaml") {
+		return true
+	}
+
+	names := []string{a.Name}
+	if a.FilePath != nil {
+		names = append(names, *a.FilePath)
+	}
+
+	for _, name := range names {
+		if slices.Contains(textExtensions, strings.ToLower(filepath.Ext(name))) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,13 +2,15 @@ package result
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"runtime"
-	"sort"
+	"slices"
 	"strings"
 
 	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/models/run"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -20,12 +22,15 @@ type client interface {
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type Parser interface {
 	Parse() ([]models.Result, error)
+	Stream(emit func(models.Result) error) error
 }
 
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type runService interface {
-	CreateRun(ctx context.Context, p, t string, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error)
+	CreateRun(ctx context.Context, p, t string, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error)
 	CompleteRun(ctx context.Context, projectCode string, runId int64) error
+	GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error)
+	ReuseRun(ctx context.Context, projectCode, title, tag string, create func(ctx context.Context) (int64, error)) (int64, bool, error)
 }
 
 const (
@@ -50,6 +55,24 @@ func (s *Service) Upload(ctx context.Context, p UploadParams) error {
 	const op = "result.parser.import"
 	logger := slog.With("op", op)
 
+	if p.Batch <= 0 {
+		return fmt.Errorf("invalid batch size %d, it must be greater than 0", p.Batch)
+	}
+
+	pl, err := newPipeline(p)
+	if err != nil {
+		return err
+	}
+
+	pl.timeline, err = s.newTimeline(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if p.Stream {
+		return s.uploadStream(ctx, p, pl)
+	}
+
 	results, err := s.parser.Parse()
 	if err != nil {
 		return fmt.Errorf("failed to parse results: %w", err)
@@ -57,25 +80,39 @@ func (s *Service) Upload(ctx context.Context, p UploadParams) error {
 
 	logger.Info("number of results found", "count", len(results))
 
+	summary := uploadSummary{parsed: len(results)}
+
+	results, summary.filtered = filterResults(pl.matcher, results)
+	if summary.filtered > 0 {
+		logger.Info("results filtered out", "count", summary.filtered)
+	}
+
+	results, summary.merged = mergeRetries(p.Retries, results)
+	if summary.merged > 0 {
+		logger.Info("retried executions merged", "count", summary.merged)
+	}
+
 	if len(results) == 0 {
 		return fmt.Errorf("no results to upload")
 	}
 
-	truncateTitles(results)
+	pl.timeline.align(results)
 
-	runID, isTestRunCreated, results, err := s.prepareRun(ctx, p, results)
-	if err != nil {
-		return err
+	if pl.splitter != nil {
+		return s.uploadSplit(ctx, p, pl, results, summary)
 	}
 
-	if p.Suite != "" {
-		prependSuite(p.Suite, results)
-	}
+	results = s.transformResults(p, pl, results)
 
-	applyResultTransforms(p, results)
+	// the stats are counted after the transforms, so they match the uploaded statuses
+	p, err = renderRunText(p, results)
+	if err != nil {
+		return err
+	}
 
-	if p.AttachmentExtensions != "" {
-		results = s.filterAttachments(results, p.AttachmentExtensions)
+	runID, isTestRunCreated, results, err := s.prepareRun(ctx, p, results)
+	if err != nil {
+		return err
 	}
 
 	err = s.uploadResults(ctx, p.Project, p.Batch, runID, results)
@@ -83,6 +120,10 @@ func (s *Service) Upload(ctx context.Context, p UploadParams) error {
 		return fmt.Errorf("failed to upload results: %w", err)
 	}
 
+	summary.uploaded = len(results)
+	summary.attachments = pl.attachments.getStats()
+	summary.log(logger)
+
 	if isTestRunCreated {
 		err := s.rs.CompleteRun(ctx, p.Project, runID)
 		if err != nil {
@@ -93,6 +134,201 @@ func (s *Service) Upload(ctx context.Context, p UploadParams) error {
 	return nil
 }
 
+// uploadStream uploads results while the parser is still reading the report.
+// Results flow through a channel and are transformed and uploaded batch by batch,
+// so at most a few batches are held in memory. The run is created from the first batch,
+// therefore its start time is derived from that batch only.
+func (s *Service) uploadStream(ctx context.Context, p UploadParams, pl *pipeline) error {
+	const op = "result.parser.uploadstream"
+	logger := slog.With("op", op)
+
+	// the report is not parsed yet, so the report stats are empty
+	p, err := renderRunText(p, nil)
+	if err != nil {
+		return err
+	}
+
+	parseCtx, cancelParse := context.WithCancel(ctx)
+	defer cancelParse()
+
+	var summary uploadSummary
+
+	resultCh := make(chan models.Result, p.Batch)
+	pg, parseCtx := errgroup.WithContext(parseCtx)
+	pg.Go(func() error {
+		defer close(resultCh)
+		return s.parser.Stream(func(result models.Result) error {
+			summary.parsed++
+			if !pl.matcher.match(result) {
+				summary.filtered++
+				return nil
+			}
+
+			select {
+			case <-parseCtx.Done():
+				return parseCtx.Err()
+			case resultCh <- result:
+				return nil
+			}
+		})
+	})
+
+	first := s.nextBatch(ctx, p, pl, resultCh)
+	if len(first) == 0 {
+		if err := pg.Wait(); err != nil {
+			return fmt.Errorf("failed to parse results: %w", err)
+		}
+		return fmt.Errorf("no results to upload")
+	}
+
+	runID, isTestRunCreated, first, err := s.prepareRun(ctx, p, first)
+	if err != nil {
+		cancelParse()
+		_ = pg.Wait()
+		return err
+	}
+
+	ug, uploadCtx := errgroup.WithContext(ctx)
+	batchCh := make(chan []models.Result, workerCount())
+	s.startUploadWorkers(uploadCtx, ug, p.Project, runID, batchCh)
+
+	ug.Go(func() error {
+		defer close(batchCh)
+		for batch := first; len(batch) > 0; batch = s.nextBatch(uploadCtx, p, pl, resultCh) {
+			summary.uploaded += len(batch)
+			logger.Debug("uploading batch", "size", len(batch), "total", summary.uploaded)
+
+			select {
+			case <-uploadCtx.Done():
+				return uploadCtx.Err()
+			case batchCh <- s.transformResults(p, pl, batch):
+			}
+		}
+		return nil
+	})
+
+	// a created run is completed also when the upload fails midway, so it is not left open
+	var runIDs []int64
+	if isTestRunCreated {
+		runIDs = append(runIDs, runID)
+	}
+
+	if err := ug.Wait(); err != nil {
+		cancelParse()
+		_ = pg.Wait()
+		return s.failUpload(ctx, logger, p.Project, runIDs, fmt.Errorf("failed to upload results to run %d: %w", runID, err))
+	}
+
+	if err := pg.Wait(); err != nil {
+		return s.failUpload(ctx, logger, p.Project, runIDs, fmt.Errorf("failed to parse results, the results parsed before the error are uploaded to run %d: %w", runID, err))
+	}
+
+	summary.attachments = pl.attachments.getStats()
+	summary.log(logger)
+
+	return s.completeRuns(ctx, p.Project, runIDs)
+}
+
+// nextBatch reads up to p.Batch results from the channel and prepares them for upload.
+// It returns a shorter batch when the channel is closed and an empty one when nothing is left.
+func (s *Service) nextBatch(ctx context.Context, p UploadParams, pl *pipeline, resultCh <-chan models.Result) []models.Result {
+	batch := make([]models.Result, 0, p.Batch)
+
+read:
+	for int64(len(batch)) < p.Batch {
+		select {
+		case <-ctx.Done():
+			return nil
+		case result, ok := <-resultCh:
+			if !ok {
+				break read
+			}
+			batch = append(batch, result)
+		}
+	}
+
+	pl.timeline.align(batch)
+
+	return batch
+}
+
+// pipeline holds the upload options compiled once per upload
+type pipeline struct {
+	matcher     *resultMatcher
+	rules       []compiledRule
+	timeline    *timeline
+	redactor    *redactor
+	attachments *attachmentProcessor
+	splitter    *splitter
+}
+
+// newPipeline validates and compiles the upload options
+func newPipeline(p UploadParams) (*pipeline, error) {
+	if err := p.Retries.validate(); err != nil {
+		return nil, err
+	}
+	if p.Stream && p.Retries != "" && p.Retries != RetryModeKeepAll {
+		return nil, fmt.Errorf("retry mode %s is not supported in stream mode", p.Retries)
+	}
+
+	matcher, err := newResultMatcher(p.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid result filter: %w", err)
+	}
+
+	rules, err := compileRules(p.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transformation rules: %w", err)
+	}
+
+	redactor, err := newRedactor(p)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := newAttachmentProcessor(p.AttachmentPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment policy: %w", err)
+	}
+
+	splitter, err := newSplitter(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pipeline{
+		matcher:     matcher,
+		rules:       rules,
+		redactor:    redactor,
+		attachments: attachments,
+		splitter:    splitter,
+	}, nil
+}
+
+// transformResults applies the upload options that work on each result independently
+func (s *Service) transformResults(p UploadParams, pl *pipeline, results []models.Result) []models.Result {
+	applyRules(pl.rules, results)
+
+	if p.Suite != "" {
+		prependSuite(p.Suite, results)
+	}
+
+	applyResultTransforms(p, results)
+	injectValues(p, results)
+
+	if p.AttachmentExtensions != "" {
+		results = s.filterAttachments(results, p.AttachmentExtensions)
+	}
+
+	pl.redactor.redactResults(results)
+	pl.attachments.processResults(results)
+
+	// rules may rename titles, so they are truncated last
+	truncateTitles(results)
+
+	return results
+}
+
 // truncateTitles truncates result titles longer than 255 runes
 func truncateTitles(results []models.Result) {
 	for i := range results {
@@ -104,22 +340,9 @@ func truncateTitles(results []models.Result) {
 	}
 }
 
-// prepareRun creates a run if needed and sorts results for existing runs
+// prepareRun creates a run unless the results are uploaded to an existing one
 func (s *Service) prepareRun(ctx context.Context, p UploadParams, results []models.Result) (int64, bool, []models.Result, error) {
 	if p.RunID != 0 {
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Execution.StartTime == nil {
-				return false
-			}
-			if results[j].Execution.StartTime == nil {
-				return true
-			}
-			return *results[i].Execution.StartTime < *results[j].Execution.StartTime
-		})
-		for i := range results {
-			results[i].Execution.StartTime = nil
-			results[i].Execution.EndTime = nil
-		}
 		return p.RunID, false, results, nil
 	}
 
@@ -130,7 +353,25 @@ func (s *Service) prepareRun(ctx context.Context, p UploadParams, results []mode
 		slog.Debug("calculated run start time", "startTime", runStartTime, "minResultStartTime", *minStartTime)
 	}
 
-	ID, err := s.rs.CreateRun(ctx, p.Project, p.Title, p.Description, "", 0, 0, []string{}, false, "", startTime)
+	tags := append([]string{}, p.RunTags...)
+	if p.ReuseTag != "" && !slices.Contains(tags, p.ReuseTag) {
+		tags = append(tags, p.ReuseTag)
+	}
+
+	create := func(ctx context.Context) (int64, error) {
+		return s.rs.CreateRun(ctx, p.Project, p.Title, p.Description, "", 0, 0, tags, false, "", startTime, p.RunCustomFields, p.RunConfigurations)
+	}
+
+	if p.ReuseRun {
+		// the shared run is completed by the last job with run complete --when-all
+		ID, _, err := s.rs.ReuseRun(ctx, p.Project, p.Title, p.ReuseTag, create)
+		if err != nil {
+			return 0, false, nil, err
+		}
+		return ID, false, results, nil
+	}
+
+	ID, err := create(ctx)
 	if err != nil {
 		return 0, false, nil, err
 	}
@@ -164,6 +405,59 @@ func applyResultTransforms(p UploadParams, results []models.Result) {
 	}
 }
 
+// injectValues merges the run-wide fields, params and tags into every result.
+// Values already set by the report win unless OverrideValues is set. Tags are appended to the "tags" field.
+func injectValues(p UploadParams, results []models.Result) {
+	if len(p.Fields) == 0 && len(p.Params) == 0 && len(p.Tags) == 0 {
+		return
+	}
+
+	for i := range results {
+		if len(p.Fields) > 0 && results[i].Fields == nil {
+			results[i].Fields = make(map[string]string, len(p.Fields))
+		}
+		mergeValues(results[i].Fields, p.Fields, p.OverrideValues)
+
+		if len(p.Params) > 0 && results[i].Params == nil {
+			results[i].Params = make(map[string]string, len(p.Params))
+		}
+		mergeValues(results[i].Params, p.Params, p.OverrideValues)
+
+		if len(p.Tags) > 0 {
+			if results[i].Fields == nil {
+				results[i].Fields = make(map[string]string, 1)
+			}
+			results[i].Fields["tags"] = mergeTags(results[i].Fields["tags"], p.Tags)
+		}
+	}
+}
+
+// mergeValues copies values into dst. Existing keys are kept unless override is set.
+func mergeValues(dst, values map[string]string, override bool) {
+	for k, v := range values {
+		if _, ok := dst[k]; ok && !override {
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// mergeTags appends tags missing from the comma-separated list
+func mergeTags(existing string, tags []string) string {
+	merged := make([]string, 0, len(tags))
+	for _, tag := range strings.Split(existing, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
 func (s *Service) uploadResults(ctx context.Context, project string, batchSize, runID int64, results []models.Result) error {
 	batchCount := (int64(len(results)) + batchSize - 1) / batchSize
 	batches := make([][]models.Result, 0, batchCount)
@@ -178,14 +472,40 @@ func (s *Service) uploadResults(ctx context.Context, project string, batchSize,
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	workerCount := runtime.NumCPU()
-	if workerCount > MaxWorkerCount {
-		workerCount = MaxWorkerCount
+	batchCh := make(chan []models.Result, workerCount())
+	s.startUploadWorkers(ctx, g, project, runID, batchCh)
+
+	g.Go(func() error {
+		defer close(batchCh)
+		for _, batch := range batches {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case batchCh <- batch:
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
 	}
 
-	batchCh := make(chan []models.Result, workerCount)
+	return nil
+}
 
-	for i := 0; i < workerCount; i++ {
+// workerCount returns the number of upload workers
+func workerCount() int {
+	count := runtime.NumCPU()
+	if count > MaxWorkerCount {
+		count = MaxWorkerCount
+	}
+	return count
+}
+
+// startUploadWorkers starts workers that upload batches from batchCh until it is closed
+func (s *Service) startUploadWorkers(ctx context.Context, g *errgroup.Group, project string, runID int64, batchCh <-chan []models.Result) {
+	for i := 0; i < workerCount(); i++ {
 		g.Go(func() error {
 			for {
 				select {
@@ -203,24 +523,6 @@ func (s *Service) uploadResults(ctx context.Context, project string, batchSize,
 			}
 		})
 	}
-
-	g.Go(func() error {
-		defer close(batchCh)
-		for _, batch := range batches {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case batchCh <- batch:
-			}
-		}
-		return nil
-	})
-
-	if err := g.Wait(); err != nil {
-		return err
-	}
-
-	return nil
 }
 
 // filterAttachments filters attachments based on file extensions
@@ -313,3 +615,30 @@ func (s *Service) findMinStartTime(results []models.Result) *float64 {
 
 	return minStartTime
 }
+
+// failUpload completes the runs created by an upload that failed, so they are not left open, and returns err
+func (s *Service) failUpload(ctx context.Context, logger *slog.Logger, project string, runIDs []int64, err error) error {
+	if len(runIDs) == 0 {
+		return err
+	}
+
+	if cerr := s.completeRuns(ctx, project, runIDs); cerr != nil {
+		logger.Error("failed to complete the runs created before the failure", "runIDs", runIDs, "error", cerr)
+	} else {
+		logger.Warn("completed the runs created before the failure", "runIDs", runIDs)
+	}
+
+	return err
+}
+
+// completeRuns completes every run, also when completing one of them fails
+func (s *Service) completeRuns(ctx context.Context, project string, runIDs []int64) error {
+	var errs []error
+	for _, runID := range runIDs {
+		if err := s.rs.CompleteRun(ctx, project, runID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
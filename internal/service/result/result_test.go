@@ -3,10 +3,13 @@ package result
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/models/run"
 	"go.uber.org/mock/gomock"
 )
 
@@ -771,6 +774,7 @@ func TestService_Upload(t *testing.T) {
 					RunID:       1,
 					Batch:       20,
 					Suite:       "",
+					StripTimes:  true,
 				},
 				err:    nil,
 				isUsed: true,
@@ -804,6 +808,7 @@ func TestService_Upload(t *testing.T) {
 					RunID:       1,
 					Batch:       20,
 					Suite:       "",
+					StripTimes:  true,
 				},
 				err:    nil,
 				isUsed: true,
@@ -893,11 +898,67 @@ func TestService_Upload(t *testing.T) {
 			wantErr:    false,
 			errMessage: "",
 		},
+		{
+			name: "all results filtered out",
+			args: args{
+				p: UploadParams{
+					Project: "project",
+					Title:   "title",
+					Batch:   20,
+					Filter: ResultFilter{
+						ExcludeStatuses: []string{"passed", "failed"},
+					},
+				},
+				isUsed: false,
+			},
+			pArgs: pArgs{
+				models: prepareModels(),
+				err:    nil,
+				isUsed: true,
+			},
+			rArgs: rArgs{
+				isUsed: false,
+			},
+			cArgs: cArgs{
+				isUsed: false,
+			},
+			wantErr:    true,
+			errMessage: "no results to upload",
+		},
+		{
+			name: "invalid filter",
+			args: args{
+				p: UploadParams{
+					Project: "project",
+					Title:   "title",
+					Batch:   20,
+					Filter: ResultFilter{
+						IncludeTitle: "(",
+					},
+				},
+				isUsed: false,
+			},
+			pArgs: pArgs{
+				isUsed: false,
+			},
+			rArgs: rArgs{
+				isUsed: false,
+			},
+			cArgs: cArgs{
+				isUsed: false,
+			},
+			wantErr:    true,
+			errMessage: "invalid result filter: invalid title pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := newFixture(t)
 
+			if tt.args.p.RunID != 0 && !tt.args.p.StripTimes {
+				f.rs.EXPECT().GetRun(gomock.Any(), tt.args.p.Project, tt.args.p.RunID).Return(run.Run{ID: tt.args.p.RunID}, nil)
+			}
+
 			if tt.pArgs.isUsed {
 				f.parser.EXPECT().Parse().Return(tt.pArgs.models, tt.pArgs.err)
 			}
@@ -915,6 +976,8 @@ func TestService_Upload(t *testing.T) {
 					false,      // isCloud
 					"",         // browser
 					gomock.Any(), // startTime
+					map[string]string(nil),
+					[]int64(nil),
 				).Return(tt.rArgs.model, tt.rArgs.err)
 			}
 
@@ -1098,6 +1161,141 @@ func TestService_Upload(t *testing.T) {
 	}
 }
 
+func TestService_Upload_Stream(t *testing.T) {
+	tests := []struct {
+		name        string
+		p           UploadParams
+		results     []models.Result
+		parseErr    error
+		createRun   bool
+		uploadErr   error
+		uploadCount int
+		complete    bool
+		wantErr     bool
+		errMessage  string
+	}{
+		{
+			name:        "success with create test run",
+			p:           UploadParams{Project: "project", Title: "title", Batch: 20, Stream: true},
+			results:     prepareModels(),
+			createRun:   true,
+			uploadCount: 1,
+			complete:    true,
+		},
+		{
+			name:        "success with existing test run in batches",
+			p:           UploadParams{Project: "project", RunID: 1, Batch: 1, Stream: true},
+			results:     prepareModels(),
+			uploadCount: 2,
+		},
+		{
+			name:       "no results",
+			p:          UploadParams{Project: "project", RunID: 1, Batch: 20, Stream: true},
+			results:    []models.Result{},
+			wantErr:    true,
+			errMessage: "no results to upload",
+		},
+		{
+			name:       "failed parser before first result",
+			p:          UploadParams{Project: "project", RunID: 1, Batch: 20, Stream: true},
+			parseErr:   errors.New("failed parser"),
+			wantErr:    true,
+			errMessage: "failed to parse results: failed parser",
+		},
+		{
+			name:        "failed parser after first batch",
+			p:           UploadParams{Project: "project", RunID: 1, Batch: 1, Stream: true},
+			results:     prepareModels(),
+			parseErr:    errors.New("failed parser"),
+			uploadCount: 2,
+			wantErr:     true,
+			errMessage:  "failed to parse results, the results parsed before the error are uploaded to run 1: failed parser",
+		},
+		{
+			name:        "failed parser after first batch with create test run",
+			p:           UploadParams{Project: "project", Title: "title", Batch: 1, Stream: true},
+			results:     prepareModels(),
+			parseErr:    errors.New("failed parser"),
+			createRun:   true,
+			uploadCount: 2,
+			complete:    true,
+			wantErr:     true,
+			errMessage:  "failed to parse results, the results parsed before the error are uploaded to run 1: failed parser",
+		},
+		{
+			name:        "failed upload",
+			p:           UploadParams{Project: "project", Title: "title", Batch: 20, Stream: true},
+			results:     prepareModels(),
+			createRun:   true,
+			uploadErr:   errors.New("failed upload data"),
+			uploadCount: 1,
+			complete:    true,
+			wantErr:     true,
+			errMessage:  "failed to upload results to run 1: failed upload data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			if tt.p.RunID != 0 {
+				f.rs.EXPECT().GetRun(gomock.Any(), tt.p.Project, tt.p.RunID).Return(run.Run{ID: tt.p.RunID}, nil)
+			}
+
+			f.parser.EXPECT().
+				Stream(gomock.Any()).
+				DoAndReturn(func(emit func(models.Result) error) error {
+					for _, r := range tt.results {
+						if err := emit(r); err != nil {
+							return err
+						}
+					}
+					return tt.parseErr
+				})
+
+			if tt.createRun {
+				f.rs.EXPECT().
+					CreateRun(gomock.Any(), tt.p.Project, tt.p.Title, tt.p.Description, "", int64(0), int64(0), []string{}, false, "", nil, map[string]string(nil), []int64(nil)).
+					Return(int64(1), nil)
+			}
+
+			if tt.uploadCount > 0 {
+				f.client.EXPECT().
+					UploadData(gomock.Any(), tt.p.Project, int64(1), gomock.Any()).
+					Return(tt.uploadErr).
+					Times(tt.uploadCount)
+			}
+
+			if tt.complete {
+				f.rs.EXPECT().CompleteRun(gomock.Any(), tt.p.Project, int64(1)).Return(nil)
+			}
+
+			s := NewService(f.client, f.parser, f.rs)
+
+			err := s.Upload(context.Background(), tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Upload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Errorf("Service.Upload() error = %v, wantErr %v", err, tt.errMessage)
+			}
+		})
+	}
+}
+
+func TestService_Upload_InvalidBatch(t *testing.T) {
+	f := newFixture(t)
+	s := NewService(f.client, f.parser, f.rs)
+
+	for _, batch := range []int64{0, -1} {
+		err := s.Upload(context.Background(), UploadParams{Project: "project", Title: "title", Batch: batch})
+		want := fmt.Sprintf("invalid batch size %d, it must be greater than 0", batch)
+		if err == nil || err.Error() != want {
+			t.Errorf("Service.Upload() error = %v, want %v", err, want)
+		}
+	}
+}
+
 func TestService_filterAttachments(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1242,3 +1440,124 @@ func TestService_shouldIncludeAttachment(t *testing.T) {
 		})
 	}
 }
+
+func TestInjectValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		p          UploadParams
+		result     models.Result
+		wantFields map[string]string
+		wantParams map[string]string
+	}{
+		{
+			name:       "nothing to inject",
+			p:          UploadParams{},
+			result:     models.Result{},
+			wantFields: nil,
+			wantParams: nil,
+		},
+		{
+			name: "inject into empty result",
+			p: UploadParams{
+				Fields: map[string]string{"environment": "staging"},
+				Params: map[string]string{"shard": "3"},
+			},
+			result:     models.Result{},
+			wantFields: map[string]string{"environment": "staging"},
+			wantParams: map[string]string{"shard": "3"},
+		},
+		{
+			name: "report values win by default",
+			p: UploadParams{
+				Fields: map[string]string{"layer": "e2e", "environment": "staging"},
+				Params: map[string]string{"browser": "firefox"},
+			},
+			result: models.Result{
+				Fields: map[string]string{"layer": "api"},
+				Params: map[string]string{"browser": "chrome"},
+			},
+			wantFields: map[string]string{"layer": "api", "environment": "staging"},
+			wantParams: map[string]string{"browser": "chrome"},
+		},
+		{
+			name: "override report values",
+			p: UploadParams{
+				Fields:         map[string]string{"layer": "e2e"},
+				Params:         map[string]string{"browser": "firefox"},
+				OverrideValues: true,
+			},
+			result: models.Result{
+				Fields: map[string]string{"layer": "api"},
+				Params: map[string]string{"browser": "chrome", "os": "linux"},
+			},
+			wantFields: map[string]string{"layer": "e2e"},
+			wantParams: map[string]string{"browser": "firefox", "os": "linux"},
+		},
+		{
+			name: "merge tags without duplicates",
+			p: UploadParams{
+				Tags: []string{"nightly", "smoke"},
+			},
+			result: models.Result{
+				Fields: map[string]string{"tags": "smoke, auth"},
+			},
+			wantFields: map[string]string{"tags": "smoke,auth,nightly"},
+			wantParams: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []models.Result{tt.result}
+			injectValues(tt.p, results)
+
+			if !reflect.DeepEqual(results[0].Fields, tt.wantFields) {
+				t.Errorf("Fields = %v, want %v", results[0].Fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(results[0].Params, tt.wantParams) {
+				t.Errorf("Params = %v, want %v", results[0].Params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestService_Upload_ReuseRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		reuseTag string
+		wantTags []string
+	}{
+		{
+			name:     "reuse by title",
+			wantTags: []string{"ci"},
+		},
+		{
+			name:     "reuse by tag",
+			reuseTag: "pipeline-77",
+			wantTags: []string{"ci", "pipeline-77"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			p := UploadParams{Project: "project", Title: "Nightly", Batch: 20, RunTags: []string{"ci"}, ReuseRun: true, ReuseTag: tt.reuseTag}
+
+			f.parser.EXPECT().Parse().Return([]models.Result{filterModel("Test 1", "passed", "sig-1", nil, nil)}, nil)
+			f.rs.EXPECT().
+				ReuseRun(gomock.Any(), "project", "Nightly", tt.reuseTag, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _, _, _ string, create func(context.Context) (int64, error)) (int64, bool, error) {
+					id, err := create(ctx)
+					return id, true, err
+				})
+			f.rs.EXPECT().
+				CreateRun(gomock.Any(), "project", "Nightly", "", "", int64(0), int64(0), tt.wantTags, false, "", nil, map[string]string(nil), []int64(nil)).
+				Return(int64(3), nil)
+			f.client.EXPECT().UploadData(gomock.Any(), "project", int64(3), gomock.Any()).Return(nil)
+
+			s := NewService(f.client, f.parser, f.rs)
+
+			if err := s.Upload(context.Background(), p); err != nil {
+				t.Errorf("Service.Upload() unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package result
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+// RetryMode controls how repeated executions of the same test are uploaded
+type RetryMode string
+
+const (
+	// RetryModeKeepAll uploads every execution as a separate result
+	RetryModeKeepAll RetryMode = "keep-all"
+	// RetryModeKeepLast uploads only the final execution
+	RetryModeKeepLast RetryMode = "keep-last"
+	// RetryModeMerge uploads the final execution with the earlier ones attached as steps
+	RetryModeMerge RetryMode = "merge"
+)
+
+// flakyField is the result field marking a test whose retries had different outcomes
+const flakyField = "isFlaky"
+
+// validate reports an error for unknown modes. An empty mode is treated as keep-all.
+func (m RetryMode) validate() error {
+	switch m {
+	case "", RetryModeKeepAll, RetryModeKeepLast, RetryModeMerge:
+		return nil
+	default:
+		return fmt.Errorf("unknown retry mode: %s. allowed modes: %s, %s, %s", m, RetryModeKeepAll, RetryModeKeepLast, RetryModeMerge)
+	}
+}
+
+// mergeRetries groups executions of the same test and collapses every group into its final execution.
+// It returns the results in the order their tests first appear and the number of collapsed executions.
+func mergeRetries(mode RetryMode, results []models.Result) ([]models.Result, int) {
+	if mode == "" || mode == RetryModeKeepAll {
+		return results, 0
+	}
+
+	groups := make(map[string][]models.Result)
+	keys := make([]string, 0)
+	for _, r := range results {
+		key := retryKey(r)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	if len(keys) == len(results) {
+		return results, 0
+	}
+
+	merged := make([]models.Result, 0, len(keys))
+	for _, key := range keys {
+		merged = append(merged, collapseAttempts(mode, groups[key]))
+	}
+
+	return merged, len(results) - len(merged)
+}
+
+// retryKey identifies a test across executions: its signature if set, otherwise its title, suite and params
+func retryKey(r models.Result) string {
+	if r.Signature != nil && *r.Signature != "" {
+		return *r.Signature
+	}
+
+	var b strings.Builder
+	b.WriteString(r.Title)
+	b.WriteString("\x00")
+	b.WriteString(suitePath(r))
+	for _, k := range slices.Sorted(maps.Keys(r.Params)) {
+		b.WriteString("\x00")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(r.Params[k])
+	}
+
+	return b.String()
+}
+
+// collapseAttempts returns the final attempt, marked flaky when the outcomes of the attempts differ
+func collapseAttempts(mode RetryMode, attempts []models.Result) models.Result {
+	if len(attempts) == 1 {
+		return attempts[0]
+	}
+
+	sortAttempts(attempts)
+
+	final := attempts[len(attempts)-1]
+
+	if isFlaky(attempts) {
+		fields := make(map[string]string, len(final.Fields)+1)
+		maps.Copy(fields, final.Fields)
+		fields[flakyField] = "true"
+		final.Fields = fields
+	}
+
+	if mode == RetryModeMerge {
+		steps := make([]models.Step, 0, len(attempts)-1+len(final.Steps))
+		for i, attempt := range attempts[:len(attempts)-1] {
+			steps = append(steps, attemptStep(i+1, len(attempts), attempt))
+		}
+		final.Steps = append(steps, final.Steps...)
+	}
+
+	return final
+}
+
+// sortAttempts orders the attempts by start time when every attempt has one and keeps the report order otherwise
+func sortAttempts(attempts []models.Result) {
+	for _, a := range attempts {
+		if a.Execution.StartTime == nil {
+			return
+		}
+	}
+
+	slices.SortStableFunc(attempts, func(a, b models.Result) int {
+		switch {
+		case *a.Execution.StartTime < *b.Execution.StartTime:
+			return -1
+		case *a.Execution.StartTime > *b.Execution.StartTime:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// isFlaky reports whether the attempts have different statuses
+func isFlaky(attempts []models.Result) bool {
+	for _, a := range attempts[1:] {
+		if !strings.EqualFold(a.Execution.Status, attempts[0].Execution.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+// attemptStep converts an earlier attempt into a step holding its outcome, steps and attachments
+func attemptStep(n, total int, attempt models.Result) models.Step {
+	comment := make([]string, 0, 2)
+	if attempt.Message != nil && *attempt.Message != "" {
+		comment = append(comment, *attempt.Message)
+	}
+	if attempt.Execution.StackTrace != nil && *attempt.Execution.StackTrace != "" {
+		comment = append(comment, *attempt.Execution.StackTrace)
+	}
+
+	return models.Step{
+		Data: models.Data{
+			Action: fmt.Sprintf("Attempt %d of %d", n, total),
+		},
+		Execution: models.StepExecution{
+			StartTime:   attempt.Execution.StartTime,
+			EndTime:     attempt.Execution.EndTime,
+			Status:      stepStatus(attempt.Execution.Status),
+			Duration:    attempt.Execution.Duration,
+			Comment:     strings.Join(comment, "\n\n"),
+			Attachments: attempt.Attachments,
+		},
+		Steps: attempt.Steps,
+	}
+}
+
+// stepStatus maps the status of a result to a step status. Steps have no invalid status, so an invalid
+// attempt becomes a failed step, and unknown statuses become blocked as in the parsers.
+func stepStatus(status string) string {
+	switch status {
+	case "passed", "failed", "skipped", "blocked":
+		return status
+	case "invalid":
+		return "failed"
+	default:
+		return "blocked"
+	}
+}
@@ -0,0 +1,169 @@
+package result
+
+import (
+	"testing"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+func attemptModel(title, signature, status string, startTime float64, message string) models.Result {
+	r := models.Result{
+		Title:     title,
+		Execution: models.Execution{Status: status, StartTime: &startTime},
+		Params:    map[string]string{"browser": "chrome"},
+	}
+	if signature != "" {
+		r.Signature = &signature
+	}
+	if message != "" {
+		r.Message = &message
+	}
+	return r
+}
+
+func TestMergeRetries(t *testing.T) {
+	results := func() []models.Result {
+		return []models.Result{
+			attemptModel("Login", "auth::login", "failed", 3000, "timeout"),
+			attemptModel("Logout", "auth::logout", "passed", 1500, ""),
+			attemptModel("Login", "auth::login", "passed", 5000, ""),
+			attemptModel("Login", "auth::login", "failed", 1000, "connection refused"),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mode       RetryMode
+		wantCount  int
+		wantMerged int
+		check      func(t *testing.T, results []models.Result)
+	}{
+		{
+			name:       "keep all",
+			mode:       RetryModeKeepAll,
+			wantCount:  4,
+			wantMerged: 0,
+		},
+		{
+			name:       "empty mode keeps all",
+			mode:       "",
+			wantCount:  4,
+			wantMerged: 0,
+		},
+		{
+			name:       "keep last",
+			mode:       RetryModeKeepLast,
+			wantCount:  2,
+			wantMerged: 2,
+			check: func(t *testing.T, results []models.Result) {
+				login := results[0]
+				if login.Title != "Login" || login.Execution.Status != "passed" {
+					t.Errorf("first result = %s %s, want final Login attempt", login.Title, login.Execution.Status)
+				}
+				if login.Fields[flakyField] != "true" {
+					t.Errorf("%s = %q, want true", flakyField, login.Fields[flakyField])
+				}
+				if len(login.Steps) != 0 {
+					t.Errorf("steps = %d, want 0", len(login.Steps))
+				}
+				if results[1].Title != "Logout" {
+					t.Errorf("second result = %s, want Logout", results[1].Title)
+				}
+				if _, ok := results[1].Fields[flakyField]; ok {
+					t.Error("single attempt marked as flaky")
+				}
+			},
+		},
+		{
+			name:       "merge",
+			mode:       RetryModeMerge,
+			wantCount:  2,
+			wantMerged: 2,
+			check: func(t *testing.T, results []models.Result) {
+				login := results[0]
+				if login.Execution.Status != "passed" {
+					t.Errorf("status = %s, want passed", login.Execution.Status)
+				}
+				if login.Fields[flakyField] != "true" {
+					t.Errorf("%s = %q, want true", flakyField, login.Fields[flakyField])
+				}
+				if len(login.Steps) != 2 {
+					t.Fatalf("steps = %d, want 2", len(login.Steps))
+				}
+				first := login.Steps[0]
+				if first.Data.Action != "Attempt 1 of 3" || first.Execution.Comment != "connection refused" {
+					t.Errorf("first step = %q %q, want attempt 1 with its message", first.Data.Action, first.Execution.Comment)
+				}
+				if login.Steps[1].Execution.Comment != "timeout" {
+					t.Errorf("second step comment = %q, want timeout", login.Steps[1].Execution.Comment)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, merged := mergeRetries(tt.mode, results())
+			if len(got) != tt.wantCount {
+				t.Errorf("mergeRetries() count = %d, want %d", len(got), tt.wantCount)
+			}
+			if merged != tt.wantMerged {
+				t.Errorf("mergeRetries() merged = %d, want %d", merged, tt.wantMerged)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestMergeRetries_WithoutSignature(t *testing.T) {
+	results := []models.Result{
+		attemptModel("Search", "", "failed", 1000, ""),
+		attemptModel("Search", "", "failed", 2000, ""),
+		attemptModel("Search", "", "passed", 3000, ""),
+	}
+	results[2].Params = map[string]string{"browser": "firefox"}
+
+	got, merged := mergeRetries(RetryModeMerge, results)
+	if merged != 1 || len(got) != 2 {
+		t.Fatalf("mergeRetries() = %d results, %d merged, want 2 results, 1 merged", len(got), merged)
+	}
+	if _, ok := got[0].Fields[flakyField]; ok {
+		t.Error("attempts with the same outcome marked as flaky")
+	}
+	if len(got[0].Steps) != 1 {
+		t.Errorf("steps = %d, want 1", len(got[0].Steps))
+	}
+}
+
+func TestMergeRetries_InvalidAttempt(t *testing.T) {
+	results := []models.Result{
+		attemptModel("Checkout", "shop::checkout", "invalid", 1000, "assertion error"),
+		attemptModel("Checkout", "shop::checkout", "untested", 2000, ""),
+		attemptModel("Checkout", "shop::checkout", "passed", 3000, ""),
+	}
+
+	got, merged := mergeRetries(RetryModeMerge, results)
+	if merged != 2 || len(got) != 1 {
+		t.Fatalf("mergeRetries() = %d results, %d merged, want 1 result, 2 merged", len(got), merged)
+	}
+	if len(got[0].Steps) != 2 {
+		t.Fatalf("steps = %d, want 2", len(got[0].Steps))
+	}
+	for i, want := range []string{"failed", "blocked"} {
+		if status := got[0].Steps[i].Execution.Status; status != want {
+			t.Errorf("step %d status = %s, want %s", i+1, status, want)
+		}
+	}
+}
+
+func TestRetryMode_validate(t *testing.T) {
+	for _, mode := range []RetryMode{"", RetryModeKeepAll, RetryModeKeepLast, RetryModeMerge} {
+		if err := mode.validate(); err != nil {
+			t.Errorf("validate(%q) unexpected error: %v", mode, err)
+		}
+	}
+	if err := RetryMode("first").validate(); err == nil {
+		t.Error("validate() expected error for unknown mode")
+	}
+}
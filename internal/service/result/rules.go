@@ -0,0 +1,241 @@
+package result
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"go.yaml.in/yaml/v3"
+)
+
+// Rule is a declarative transformation applied to every result matching its conditions.
+// The actions of a rule are applied in the order the fields are declared below.
+type Rule struct {
+	Name             string            `yaml:"name"`
+	When             RuleCondition     `yaml:"when"`
+	SetStatus        string            `yaml:"set_status"`
+	RenameTitle      *RenameTitle      `yaml:"rename_title"`
+	SetFields        map[string]string `yaml:"set_fields"`
+	RemoveFields     []string          `yaml:"remove_fields"`
+	SetParams        map[string]string `yaml:"set_params"`
+	RemoveParams     []string          `yaml:"remove_params"`
+	MoveParamToSuite string            `yaml:"move_param_to_suite"`
+}
+
+// RuleCondition selects the results a rule applies to. A rule needs at least one condition,
+// All matches every result.
+type RuleCondition struct {
+	// All matches every result
+	All bool `yaml:"all"`
+	// Statuses matches any of the given statuses
+	Statuses []string `yaml:"status"`
+	// Suite is a glob pattern matched against the suite path and its parents
+	Suite string `yaml:"suite"`
+	// Title is a regular expression matched against the title
+	Title string `yaml:"title"`
+	// Message is a regular expression matched against the message and the stack trace
+	Message string `yaml:"message"`
+	// Fields matches when all the given fields have the given values
+	Fields map[string]string `yaml:"fields"`
+	// Params matches when all the given params have the given values
+	Params map[string]string `yaml:"params"`
+}
+
+// RenameTitle replaces the parts of the title matching Pattern with Replacement.
+// Replacement may reference capture groups like $1.
+type RenameTitle struct {
+	Pattern     string `yaml:"pattern"`
+	Replacement string `yaml:"replacement"`
+}
+
+// ruleFile is the layout of a rules file
+type ruleFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads transformation rules from a YAML or JSON file
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	// unknown keys are rejected, otherwise a misspelled condition would be dropped and the rule would match every result
+	var f ruleFile
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	d.KnownFields(true)
+	if err := d.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	return f.Rules, nil
+}
+
+// compiledRule is a Rule with compiled patterns
+type compiledRule struct {
+	Rule
+	title   *regexp.Regexp
+	message *regexp.Regexp
+	rename  *regexp.Regexp
+}
+
+// compileRules validates the rules and compiles their patterns
+func compileRules(rules []Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+
+	for i, rule := range rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		if !rule.hasAction() {
+			return nil, fmt.Errorf("rule %s has no actions", name)
+		}
+
+		if !rule.When.hasCondition() {
+			return nil, fmt.Errorf("rule %s has no conditions, set all: true to apply it to every result", name)
+		}
+
+		c := compiledRule{Rule: rule}
+
+		if rule.When.Suite != "" {
+			if _, err := path.Match(rule.When.Suite, ""); err != nil {
+				return nil, fmt.Errorf("rule %s: invalid suite pattern %q: %w", name, rule.When.Suite, err)
+			}
+		}
+
+		var err error
+		if c.title, err = compileOptional(rule.When.Title); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid title pattern: %w", name, err)
+		}
+		if c.message, err = compileOptional(rule.When.Message); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid message pattern: %w", name, err)
+		}
+		if rule.RenameTitle != nil {
+			if rule.RenameTitle.Pattern == "" {
+				return nil, fmt.Errorf("rule %s: rename_title requires a pattern", name)
+			}
+			if c.rename, err = regexp.Compile(rule.RenameTitle.Pattern); err != nil {
+				return nil, fmt.Errorf("rule %s: invalid rename pattern: %w", name, err)
+			}
+		}
+
+		compiled = append(compiled, c)
+	}
+
+	return compiled, nil
+}
+
+// compileOptional compiles the pattern unless it is empty
+func compileOptional(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// hasAction reports whether the rule changes anything
+func (r Rule) hasAction() bool {
+	return r.SetStatus != "" || r.RenameTitle != nil ||
+		len(r.SetFields) > 0 || len(r.RemoveFields) > 0 ||
+		len(r.SetParams) > 0 || len(r.RemoveParams) > 0 ||
+		r.MoveParamToSuite != ""
+}
+
+// hasCondition reports whether any condition is set
+func (c RuleCondition) hasCondition() bool {
+	return c.All || len(c.Statuses) > 0 || c.Suite != "" || c.Title != "" || c.Message != "" ||
+		len(c.Fields) > 0 || len(c.Params) > 0
+}
+
+// applyRules evaluates the rules in order against every result
+func applyRules(rules []compiledRule, results []models.Result) {
+	for i := range results {
+		for _, rule := range rules {
+			if rule.matches(results[i]) {
+				rule.apply(&results[i])
+			}
+		}
+	}
+}
+
+// matches reports whether the result satisfies all rule conditions
+func (r compiledRule) matches(result models.Result) bool {
+	w := r.When
+
+	if len(w.Statuses) > 0 && !containsFold(w.Statuses, result.Execution.Status) {
+		return false
+	}
+	if w.Suite != "" && !matchSuite([]string{w.Suite}, suitePath(result)) {
+		return false
+	}
+	if r.title != nil && !r.title.MatchString(result.Title) {
+		return false
+	}
+	if r.message != nil {
+		message := ""
+		if result.Message != nil {
+			message = *result.Message
+		}
+		stackTrace := ""
+		if result.Execution.StackTrace != nil {
+			stackTrace = *result.Execution.StackTrace
+		}
+		if !r.message.MatchString(message) && !r.message.MatchString(stackTrace) {
+			return false
+		}
+	}
+	if len(w.Fields) > 0 && !matchFields(w.Fields, result.Fields, true) {
+		return false
+	}
+	if len(w.Params) > 0 && !matchFields(w.Params, result.Params, true) {
+		return false
+	}
+
+	return true
+}
+
+// apply applies the rule actions to the result
+func (r compiledRule) apply(result *models.Result) {
+	if r.SetStatus != "" {
+		result.Execution.Status = r.SetStatus
+	}
+
+	if r.rename != nil {
+		result.Title = r.rename.ReplaceAllString(result.Title, r.RenameTitle.Replacement)
+	}
+
+	if len(r.SetFields) > 0 && result.Fields == nil {
+		result.Fields = make(map[string]string, len(r.SetFields))
+	}
+	for k, v := range r.SetFields {
+		result.Fields[k] = v
+	}
+	for _, k := range r.RemoveFields {
+		delete(result.Fields, k)
+	}
+
+	if len(r.SetParams) > 0 && result.Params == nil {
+		result.Params = make(map[string]string, len(r.SetParams))
+	}
+	for k, v := range r.SetParams {
+		result.Params[k] = v
+	}
+	for _, k := range r.RemoveParams {
+		delete(result.Params, k)
+	}
+
+	if r.MoveParamToSuite != "" {
+		if v, ok := result.Params[r.MoveParamToSuite]; ok {
+			data := result.Relations.Suite.Data
+			result.Relations.Suite.Data = append(data[:len(data):len(data)], models.SuiteData{Title: v})
+			delete(result.Params, r.MoveParamToSuite)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package result
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+func ruleModel() models.Result {
+	message := "request failed: Timeout after 30s"
+	return models.Result{
+		Title:     "test_login_works",
+		Execution: models.Execution{Status: "failed"},
+		Message:   &message,
+		Fields:    map[string]string{"severity": "major"},
+		Params:    map[string]string{"browser": "chrome", "retry": "1"},
+		Relations: models.Relation{Suite: models.Suite{Data: []models.SuiteData{
+			{Title: "E2E"},
+			{Title: "Auth"},
+		}}},
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []Rule
+		check func(t *testing.T, r models.Result)
+	}{
+		{
+			name: "set status when message matches",
+			rules: []Rule{
+				{When: RuleCondition{Message: "(?i)timeout"}, SetStatus: "blocked"},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Execution.Status != "blocked" {
+					t.Errorf("status = %v, want blocked", r.Execution.Status)
+				}
+			},
+		},
+		{
+			name: "status unchanged when message does not match",
+			rules: []Rule{
+				{When: RuleCondition{Message: "connection refused"}, SetStatus: "blocked"},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Execution.Status != "failed" {
+					t.Errorf("status = %v, want failed", r.Execution.Status)
+				}
+			},
+		},
+		{
+			name: "set fields for suite glob",
+			rules: []Rule{
+				{When: RuleCondition{Suite: "E2E/*"}, SetFields: map[string]string{"layer": "e2e"}},
+				{When: RuleCondition{Suite: "Unit/*"}, SetFields: map[string]string{"layer": "unit"}},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Fields["layer"] != "e2e" {
+					t.Errorf("layer = %v, want e2e", r.Fields["layer"])
+				}
+			},
+		},
+		{
+			name: "remove fields",
+			rules: []Rule{
+				{When: RuleCondition{All: true}, RemoveFields: []string{"severity"}},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if _, ok := r.Fields["severity"]; ok {
+					t.Error("severity field was not removed")
+				}
+			},
+		},
+		{
+			name: "rename title with capture groups",
+			rules: []Rule{
+				{When: RuleCondition{All: true}, RenameTitle: &RenameTitle{Pattern: "^test_(.*)$", Replacement: "${1}"}},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Title != "login_works" {
+					t.Errorf("title = %v, want login_works", r.Title)
+				}
+			},
+		},
+		{
+			name: "set and remove params",
+			rules: []Rule{
+				{When: RuleCondition{Params: map[string]string{"browser": "chrome"}}, SetParams: map[string]string{"engine": "blink"}, RemoveParams: []string{"retry"}},
+			},
+			check: func(t *testing.T, r models.Result) {
+				want := map[string]string{"browser": "chrome", "engine": "blink"}
+				if !reflect.DeepEqual(r.Params, want) {
+					t.Errorf("params = %v, want %v", r.Params, want)
+				}
+			},
+		},
+		{
+			name: "move param into suite",
+			rules: []Rule{
+				{When: RuleCondition{All: true}, MoveParamToSuite: "browser"},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if _, ok := r.Params["browser"]; ok {
+					t.Error("browser param was not removed")
+				}
+				if got := suitePath(r); got != "E2E/Auth/chrome" {
+					t.Errorf("suite = %v, want E2E/Auth/chrome", got)
+				}
+			},
+		},
+		{
+			name: "rules are evaluated in order",
+			rules: []Rule{
+				{When: RuleCondition{Statuses: []string{"failed"}}, SetStatus: "blocked"},
+				{When: RuleCondition{Statuses: []string{"blocked"}}, SetFields: map[string]string{"flaky": "maybe"}},
+				{When: RuleCondition{Statuses: []string{"failed"}}, SetFields: map[string]string{"flaky": "no"}},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Fields["flaky"] != "maybe" {
+					t.Errorf("flaky = %v, want maybe", r.Fields["flaky"])
+				}
+			},
+		},
+		{
+			name: "all conditions must match",
+			rules: []Rule{
+				{When: RuleCondition{Title: "login", Fields: map[string]string{"severity": "minor"}}, SetStatus: "skipped"},
+			},
+			check: func(t *testing.T, r models.Result) {
+				if r.Execution.Status != "failed" {
+					t.Errorf("status = %v, want failed", r.Execution.Status)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := compileRules(tt.rules)
+			if err != nil {
+				t.Fatalf("compileRules() unexpected error: %v", err)
+			}
+
+			results := []models.Result{ruleModel()}
+			applyRules(rules, results)
+			tt.check(t, results[0])
+		})
+	}
+}
+
+func TestCompileRules_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      []Rule
+		errMessage string
+	}{
+		{
+			name:       "no actions",
+			rules:      []Rule{{Name: "empty", When: RuleCondition{Title: "x"}}},
+			errMessage: "rule empty has no actions",
+		},
+		{
+			name:       "no conditions",
+			rules:      []Rule{{Name: "everything", SetStatus: "blocked"}},
+			errMessage: "rule everything has no conditions, set all: true to apply it to every result",
+		},
+		{
+			name:       "invalid message pattern",
+			rules:      []Rule{{When: RuleCondition{Message: "("}, SetStatus: "blocked"}},
+			errMessage: "rule #1: invalid message pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:       "invalid suite pattern",
+			rules:      []Rule{{When: RuleCondition{Suite: "["}, SetStatus: "blocked"}},
+			errMessage: "rule #1: invalid suite pattern \"[\": syntax error in pattern",
+		},
+		{
+			name:       "rename without pattern",
+			rules:      []Rule{{When: RuleCondition{All: true}, RenameTitle: &RenameTitle{Replacement: "x"}}},
+			errMessage: "rule #1: rename_title requires a pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRules(tt.rules)
+			if err == nil {
+				t.Fatal("compileRules() expected error but got none")
+			}
+			if err.Error() != tt.errMessage {
+				t.Errorf("compileRules() error = %v, want %v", err, tt.errMessage)
+			}
+		})
+	}
+}
+
+func TestLoadRules_UnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	content := "rules:\n  - when:\n      mesage: timeout\n    set_status: blocked\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write rules file: %v", err)
+	}
+
+	_, err := LoadRules(path)
+	if err == nil || !strings.Contains(err.Error(), "field mesage not found") {
+		t.Errorf("LoadRules() error = %v, want unknown field mesage", err)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "rules.yaml",
+			content: `rules:
+  - name: timeouts
+    when:
+      message: timeout
+    set_status: blocked
+  - when:
+      suite: "E2E/*"
+    set_fields:
+      layer: e2e
+`,
+		},
+		{
+			name: "json",
+			file: "rules.json",
+			content: `{"rules": [
+  {"name": "timeouts", "when": {"message": "timeout"}, "set_status": "blocked"},
+  {"when": {"suite": "E2E/*"}, "set_fields": {"layer": "e2e"}}
+]}`,
+		},
+	}
+	want := []Rule{
+		{Name: "timeouts", When: RuleCondition{Message: "timeout"}, SetStatus: "blocked"},
+		{When: RuleCondition{Suite: "E2E/*"}, SetFields: map[string]string{"layer": "e2e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write rules file: %v", err)
+			}
+
+			rules, err := LoadRules(path)
+			if err != nil {
+				t.Fatalf("LoadRules() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rules, want) {
+				t.Errorf("LoadRules() = %+v, want %+v", rules, want)
+			}
+		})
+	}
+}
+
+func TestTransformResults_TruncatesRenamedTitles(t *testing.T) {
+	pl, err := newPipeline(UploadParams{Rules: []Rule{
+		{When: RuleCondition{All: true}, RenameTitle: &RenameTitle{Pattern: "^(.*)$", Replacement: strings.Repeat("x", 300)}},
+	}})
+	if err != nil {
+		t.Fatalf("newPipeline() unexpected error: %v", err)
+	}
+
+	results := (&Service{}).transformResults(UploadParams{}, pl, []models.Result{ruleModel()})
+	if got := len([]rune(results[0].Title)); got != 255 {
+		t.Errorf("title length = %d, want 255", got)
+	}
+}
@@ -0,0 +1,212 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"text/template"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/tmpl"
+)
+
+const (
+	// splitByParam partitions results by the value of a parameter
+	splitByParam = "param"
+	// splitBySuiteLevel partitions results by the suite title at a level of the suite path
+	splitBySuiteLevel = "suite-level"
+
+	// DefaultSplitTitle is the run title template used when none is given
+	DefaultSplitTitle = "{{.Title}} - {{.Value}}"
+
+	// otherPartition holds the results without a value to split by
+	otherPartition = "other"
+)
+
+// splitTitleData is passed to the run title template of every partition.
+// The stats of the embedded template data are counted from the results of the partition.
+type splitTitleData struct {
+	tmpl.Data
+	// Title is the title of the upload
+	Title string
+	// Value is the parameter value or suite title of the partition
+	Value string
+}
+
+// splitter partitions results into several runs
+type splitter struct {
+	kind  string
+	param string
+	level int
+	title *template.Template
+}
+
+// partition is a group of results uploaded into one run
+type partition struct {
+	value   string
+	results []models.Result
+}
+
+// newSplitter parses a split option like param:browser or suite-level:1. It returns nil when splitting is disabled.
+func newSplitter(p UploadParams) (*splitter, error) {
+	if p.SplitBy == "" {
+		return nil, nil
+	}
+	if p.RunID != 0 {
+		return nil, fmt.Errorf("split by %s can not be used with an existing run", p.SplitBy)
+	}
+	if p.Stream {
+		return nil, fmt.Errorf("split by %s is not supported in stream mode", p.SplitBy)
+	}
+	// a reused run is matched by the tag alone, so every partition would end up in the first run
+	if p.ReuseRun {
+		return nil, fmt.Errorf("split by %s can not be used with a reused run", p.SplitBy)
+	}
+
+	kind, value, ok := strings.Cut(p.SplitBy, ":")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("invalid split option %q, expected param:<name> or suite-level:<n>", p.SplitBy)
+	}
+
+	sp := &splitter{kind: kind}
+	switch kind {
+	case splitByParam:
+		sp.param = value
+	case splitBySuiteLevel:
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 1 {
+			return nil, fmt.Errorf("invalid suite level %q, expected a number starting from 1", value)
+		}
+		sp.level = level
+	default:
+		return nil, fmt.Errorf("unknown split kind %q, allowed kinds: %s, %s", kind, splitByParam, splitBySuiteLevel)
+	}
+
+	titleTemplate := p.SplitTitle
+	if titleTemplate == "" {
+		titleTemplate = DefaultSplitTitle
+	}
+
+	t, err := tmpl.Parse("split title", titleTemplate)
+	if err != nil {
+		return nil, err
+	}
+	sp.title = t
+
+	return sp, nil
+}
+
+// partition groups the results by their split value in the order the values first appear
+func (sp *splitter) partition(results []models.Result) []partition {
+	index := make(map[string]int)
+	partitions := make([]partition, 0)
+
+	for _, r := range results {
+		value := sp.value(r)
+		i, ok := index[value]
+		if !ok {
+			i = len(partitions)
+			index[value] = i
+			partitions = append(partitions, partition{value: value})
+		}
+		partitions[i].results = append(partitions[i].results, r)
+	}
+
+	return partitions
+}
+
+// value returns the split value of the result
+func (sp *splitter) value(r models.Result) string {
+	var v string
+	switch sp.kind {
+	case splitByParam:
+		v = r.Params[sp.param]
+	case splitBySuiteLevel:
+		titles := make([]string, 0, len(r.Relations.Suite.Data))
+		for _, s := range r.Relations.Suite.Data {
+			if s.Title != "" {
+				titles = append(titles, s.Title)
+			}
+		}
+		if len(titles) >= sp.level {
+			v = titles[sp.level-1]
+		}
+	}
+
+	if v == "" {
+		return otherPartition
+	}
+	return v
+}
+
+// runTitle renders the run title of the partition
+func (sp *splitter) runTitle(p UploadParams, part partition) (string, error) {
+	data := splitTitleData{Title: p.Title, Value: part.value}
+	if p.TemplateData != nil {
+		data.Data = *p.TemplateData
+	}
+	data.Stats = countStats(part.results)
+
+	var b strings.Builder
+	if err := sp.title.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render run title: %w", err)
+	}
+	return b.String(), nil
+}
+
+// uploadSplit creates a run for every partition, uploads its results and completes the runs.
+// When a partition fails, the runs created so far are completed before the error is returned.
+func (s *Service) uploadSplit(ctx context.Context, p UploadParams, pl *pipeline, results []models.Result, summary uploadSummary) error {
+	const op = "result.parser.uploadsplit"
+	logger := slog.With("op", op)
+
+	// results are partitioned before the transforms, which may move or drop the values to split by
+	partitions := pl.splitter.partition(results)
+	logger.Info("results split into runs", "count", len(partitions))
+
+	transformed := make([]models.Result, 0, len(results))
+	for i := range partitions {
+		partitions[i].results = s.transformResults(p, pl, partitions[i].results)
+		transformed = append(transformed, partitions[i].results...)
+	}
+
+	// the stats are counted after the transforms, so they match the uploaded statuses
+	p, err := renderRunText(p, transformed)
+	if err != nil {
+		return err
+	}
+
+	runIDs := make([]int64, 0, len(partitions))
+	for _, part := range partitions {
+		title, err := pl.splitter.runTitle(p, part)
+		if err != nil {
+			return s.failUpload(ctx, logger, p.Project, runIDs, err)
+		}
+
+		pp := p
+		pp.Title = title
+
+		runID, created, batch, err := s.prepareRun(ctx, pp, part.results)
+		if err != nil {
+			return s.failUpload(ctx, logger, p.Project, runIDs, err)
+		}
+		if created {
+			runIDs = append(runIDs, runID)
+		}
+
+		err = s.uploadResults(ctx, p.Project, p.Batch, runID, batch)
+		if err != nil {
+			return s.failUpload(ctx, logger, p.Project, runIDs, fmt.Errorf("failed to upload results to run %d: %w", runID, err))
+		}
+
+		summary.uploaded += len(batch)
+		logger.Info("uploaded results", "value", part.value, "runID", runID, "title", title, "count", len(batch))
+	}
+
+	summary.attachments = pl.attachments.getStats()
+	summary.log(logger)
+
+	return s.completeRuns(ctx, p.Project, runIDs)
+}
@@ -0,0 +1,224 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/tmpl"
+	"go.uber.org/mock/gomock"
+)
+
+func splitModel(title, browser string, suites ...string) models.Result {
+	r := filterModel(title, "passed", title, suites, nil)
+	if browser != "" {
+		r.Params = map[string]string{"browser": browser}
+	}
+	return r
+}
+
+func TestSplitter_partition(t *testing.T) {
+	results := []models.Result{
+		splitModel("Test 1", "chrome", "Web", "Auth"),
+		splitModel("Test 2", "firefox", "Web", "Cart"),
+		splitModel("Test 3", "chrome", "Api"),
+		splitModel("Test 4", ""),
+	}
+
+	tests := []struct {
+		name       string
+		splitBy    string
+		wantValues []string
+		wantCounts []int
+	}{
+		{
+			name:       "by param",
+			splitBy:    "param:browser",
+			wantValues: []string{"chrome", "firefox", "other"},
+			wantCounts: []int{2, 1, 1},
+		},
+		{
+			name:       "by first suite level",
+			splitBy:    "suite-level:1",
+			wantValues: []string{"Web", "Api", "other"},
+			wantCounts: []int{2, 1, 1},
+		},
+		{
+			name:       "by second suite level",
+			splitBy:    "suite-level:2",
+			wantValues: []string{"Auth", "Cart", "other"},
+			wantCounts: []int{1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := newSplitter(UploadParams{SplitBy: tt.splitBy})
+			if err != nil {
+				t.Fatalf("newSplitter() unexpected error: %v", err)
+			}
+
+			partitions := sp.partition(results)
+			if len(partitions) != len(tt.wantValues) {
+				t.Fatalf("partitions = %d, want %d", len(partitions), len(tt.wantValues))
+			}
+			for i, part := range partitions {
+				if part.value != tt.wantValues[i] || len(part.results) != tt.wantCounts[i] {
+					t.Errorf("partition %d = %s (%d), want %s (%d)", i, part.value, len(part.results), tt.wantValues[i], tt.wantCounts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewSplitter_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		p          UploadParams
+		errMessage string
+	}{
+		{
+			name:       "existing run",
+			p:          UploadParams{SplitBy: "param:browser", RunID: 1},
+			errMessage: "split by param:browser can not be used with an existing run",
+		},
+		{
+			name:       "stream mode",
+			p:          UploadParams{SplitBy: "param:browser", Stream: true},
+			errMessage: "split by param:browser is not supported in stream mode",
+		},
+		{
+			name:       "missing value",
+			p:          UploadParams{SplitBy: "param"},
+			errMessage: "invalid split option \"param\", expected param:<name> or suite-level:<n>",
+		},
+		{
+			name:       "invalid level",
+			p:          UploadParams{SplitBy: "suite-level:0"},
+			errMessage: "invalid suite level \"0\", expected a number starting from 1",
+		},
+		{
+			name:       "unknown kind",
+			p:          UploadParams{SplitBy: "field:layer"},
+			errMessage: "unknown split kind \"field\", allowed kinds: param, suite-level",
+		},
+		{
+			name:       "reused run",
+			p:          UploadParams{SplitBy: "param:browser", ReuseRun: true, ReuseTag: "pipeline-1"},
+			errMessage: "split by param:browser can not be used with a reused run",
+		},
+		{
+			name:       "invalid title template",
+			p:          UploadParams{SplitBy: "param:browser", SplitTitle: "{{.Value"},
+			errMessage: "failed to parse split title template: template: split title:1: unclosed action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSplitter(tt.p)
+			if err == nil {
+				t.Fatal("newSplitter() expected error but got none")
+			}
+			if err.Error() != tt.errMessage {
+				t.Errorf("newSplitter() error = %v, want %v", err, tt.errMessage)
+			}
+		})
+	}
+}
+
+func TestService_Upload_Split(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         UploadParams
+		uploadErr error
+		failRun   int64
+		wantRuns  []string
+		// wantDescription is the rendered description of the runs, the description of p when empty
+		wantDescription string
+		wantErr         bool
+		errMessage      string
+	}{
+		{
+			name:     "one run per browser",
+			p:        UploadParams{Project: "project", Title: "Nightly", Batch: 20, SplitBy: "param:browser"},
+			wantRuns: []string{"Nightly - chrome", "Nightly - firefox"},
+		},
+		{
+			name:     "custom title template",
+			p:        UploadParams{Project: "project", Title: "Nightly", Batch: 20, SplitBy: "param:browser", SplitTitle: "{{.Value}} ({{.Title}})"},
+			wantRuns: []string{"chrome (Nightly)", "firefox (Nightly)"},
+		},
+		{
+			name: "title template with template data and stats after transforms",
+			p: UploadParams{
+				Project: "project", Title: "Nightly", Batch: 20, SplitBy: "param:browser",
+				SplitTitle:   "{{.Title}} {{.Branch | upper}} {{.Value}}: {{.Stats.Failed}}/{{.Stats.Total}} failed",
+				Description:  "{{.Stats.Failed}} failed",
+				Statuses:     map[string]string{"passed": "failed"},
+				TemplateData: &tmpl.Data{Branch: "main"},
+			},
+			wantRuns:        []string{"Nightly MAIN chrome: 2/2 failed", "Nightly MAIN firefox: 1/1 failed"},
+			wantDescription: "3 failed",
+		},
+		{
+			name:       "failed upload",
+			p:          UploadParams{Project: "project", Title: "Nightly", Batch: 20, SplitBy: "param:browser"},
+			uploadErr:  errors.New("failed upload data"),
+			failRun:    1,
+			wantRuns:   []string{"Nightly - chrome"},
+			wantErr:    true,
+			errMessage: "failed to upload results to run 1: failed upload data",
+		},
+		{
+			name:       "failed upload of the second run",
+			p:          UploadParams{Project: "project", Title: "Nightly", Batch: 20, SplitBy: "param:browser"},
+			uploadErr:  errors.New("failed upload data"),
+			failRun:    2,
+			wantRuns:   []string{"Nightly - chrome", "Nightly - firefox"},
+			wantErr:    true,
+			errMessage: "failed to upload results to run 2: failed upload data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			f.parser.EXPECT().Parse().Return([]models.Result{
+				splitModel("Test 1", "chrome"),
+				splitModel("Test 2", "firefox"),
+				splitModel("Test 3", "chrome"),
+			}, nil)
+
+			description := tt.p.Description
+			if tt.wantDescription != "" {
+				description = tt.wantDescription
+			}
+
+			for i, title := range tt.wantRuns {
+				runID := int64(i + 1)
+				f.rs.EXPECT().
+					CreateRun(gomock.Any(), tt.p.Project, title, description, "", int64(0), int64(0), []string{}, false, "", nil, map[string]string(nil), []int64(nil)).
+					Return(runID, nil)
+				var uploadErr error
+				if runID == tt.failRun {
+					uploadErr = tt.uploadErr
+				}
+				f.client.EXPECT().
+					UploadData(gomock.Any(), tt.p.Project, runID, gomock.Any()).
+					Return(uploadErr)
+				// runs created before a failure are completed too
+				f.rs.EXPECT().CompleteRun(gomock.Any(), tt.p.Project, runID).Return(nil)
+			}
+
+			s := NewService(f.client, f.parser, f.rs)
+
+			err := s.Upload(context.Background(), tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Upload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Errorf("Service.Upload() error = %v, wantErr %v", err, tt.errMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package result
+
+import "log/slog"
+
+// uploadSummary holds the counters reported when an upload finishes
+type uploadSummary struct {
+	parsed      int
+	filtered    int
+	merged      int
+	uploaded    int
+	attachments attachmentStats
+}
+
+// log writes the summary to the logger
+func (s *uploadSummary) log(logger *slog.Logger) {
+	args := []any{
+		"parsed", s.parsed,
+		"filtered", s.filtered,
+		"merged", s.merged,
+		"uploaded", s.uploaded,
+	}
+
+	if s.attachments != (attachmentStats{}) {
+		args = append(args, slog.Group("attachments",
+			"skipped", s.attachments.skipped,
+			"truncated", s.attachments.truncated,
+			"compressed", s.attachments.compressed,
+			"downscaled", s.attachments.downscaled,
+			"saved_bytes", s.attachments.savedBytes,
+		))
+	}
+
+	logger.Info("upload summary", args...)
+}
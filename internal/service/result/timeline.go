@@ -0,0 +1,77 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+)
+
+// timeline orders results uploaded to an existing run and checks their timestamps against the run
+type timeline struct {
+	// runStart is the start time of the run in milliseconds, nil when unknown
+	runStart *float64
+	// strip drops the timestamps of every result
+	strip bool
+}
+
+// newTimeline returns the timeline of the existing run or nil when a new run will be created
+func (s *Service) newTimeline(ctx context.Context, p UploadParams) (*timeline, error) {
+	if p.RunID == 0 {
+		return nil, nil
+	}
+
+	if p.StripTimes {
+		return &timeline{strip: true}, nil
+	}
+
+	r, err := s.rs.GetRun(ctx, p.Project, p.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run %d: %w", p.RunID, err)
+	}
+
+	t := &timeline{}
+	if r.StartTime != nil {
+		runStart := float64(r.StartTime.UnixMilli())
+		t.runStart = &runStart
+	}
+
+	return t, nil
+}
+
+// align sorts the results by start time and drops the timestamps that can not be placed in the run:
+// all of them when stripping is requested, otherwise only those of results started before the run.
+func (t *timeline) align(results []models.Result) {
+	if t == nil {
+		return
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Execution.StartTime == nil {
+			return false
+		}
+		if results[j].Execution.StartTime == nil {
+			return true
+		}
+		return *results[i].Execution.StartTime < *results[j].Execution.StartTime
+	})
+
+	dropped := 0
+	for i := range results {
+		e := &results[i].Execution
+		if !t.strip && (e.StartTime == nil || t.runStart == nil || *e.StartTime >= *t.runStart) {
+			continue
+		}
+		if !t.strip {
+			dropped++
+		}
+		e.StartTime = nil
+		e.EndTime = nil
+	}
+
+	if dropped > 0 {
+		slog.Warn("results started before the run, their timestamps are dropped", "count", dropped)
+	}
+}
@@ -0,0 +1,154 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"go.uber.org/mock/gomock"
+)
+
+func timedModel(title string, startTime float64) models.Result {
+	endTime := startTime + 100
+	return models.Result{
+		Title:     title,
+		Execution: models.Execution{StartTime: &startTime, EndTime: &endTime},
+	}
+}
+
+func TestTimeline_align(t *testing.T) {
+	runStart := float64(2000)
+
+	tests := []struct {
+		name      string
+		timeline  *timeline
+		wantOrder []string
+		wantTimes []bool
+	}{
+		{
+			name:      "nil timeline keeps results",
+			timeline:  nil,
+			wantOrder: []string{"late", "early", "middle"},
+			wantTimes: []bool{true, true, true},
+		},
+		{
+			name:      "preserve times after run start",
+			timeline:  &timeline{runStart: &runStart},
+			wantOrder: []string{"early", "middle", "late"},
+			wantTimes: []bool{false, true, true},
+		},
+		{
+			name:      "preserve times when run start is unknown",
+			timeline:  &timeline{},
+			wantOrder: []string{"early", "middle", "late"},
+			wantTimes: []bool{true, true, true},
+		},
+		{
+			name:      "strip times",
+			timeline:  &timeline{strip: true},
+			wantOrder: []string{"early", "middle", "late"},
+			wantTimes: []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []models.Result{
+				timedModel("late", 3000),
+				timedModel("early", 1000),
+				timedModel("middle", 2000),
+			}
+
+			tt.timeline.align(results)
+
+			for i, r := range results {
+				if r.Title != tt.wantOrder[i] {
+					t.Errorf("result %d = %s, want %s", i, r.Title, tt.wantOrder[i])
+				}
+				hasTimes := r.Execution.StartTime != nil && r.Execution.EndTime != nil
+				if hasTimes != tt.wantTimes[i] {
+					t.Errorf("result %s has times = %v, want %v", r.Title, hasTimes, tt.wantTimes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_newTimeline(t *testing.T) {
+	runStart := time.UnixMilli(5000)
+
+	tests := []struct {
+		name       string
+		p          UploadParams
+		getRun     bool
+		run        run.Run
+		err        error
+		want       *timeline
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "new run",
+			p:    UploadParams{Project: "project"},
+			want: nil,
+		},
+		{
+			name: "strip times without fetching the run",
+			p:    UploadParams{Project: "project", RunID: 1, StripTimes: true},
+			want: &timeline{strip: true},
+		},
+		{
+			name:   "existing run start time",
+			p:      UploadParams{Project: "project", RunID: 1},
+			getRun: true,
+			run:    run.Run{ID: 1, StartTime: &runStart},
+			want:   &timeline{runStart: func() *float64 { v := float64(5000); return &v }()},
+		},
+		{
+			name:       "failed to get run",
+			p:          UploadParams{Project: "project", RunID: 1},
+			getRun:     true,
+			err:        errors.New("not found"),
+			wantErr:    true,
+			errMessage: "failed to get run 1: not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			if tt.getRun {
+				f.rs.EXPECT().GetRun(gomock.Any(), tt.p.Project, tt.p.RunID).Return(tt.run, tt.err)
+			}
+
+			s := NewService(f.client, f.parser, f.rs)
+
+			got, err := s.newTimeline(context.Background(), tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTimeline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if err.Error() != tt.errMessage {
+					t.Errorf("newTimeline() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("newTimeline() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if got.strip != tt.want.strip {
+				t.Errorf("newTimeline() strip = %v, want %v", got.strip, tt.want.strip)
+			}
+			if (got.runStart == nil) != (tt.want.runStart == nil) ||
+				(got.runStart != nil && *got.runStart != *tt.want.runStart) {
+				t.Errorf("newTimeline() runStart = %v, want %v", got.runStart, tt.want.runStart)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package result
+
+import (
+	"strings"
+
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/tmpl"
+)
+
+// renderRunText renders the title and description templates of the run created by the upload
+// and appends the CI description. Report stats are counted from the given results.
+func renderRunText(p UploadParams, results []models.Result) (UploadParams, error) {
+	if p.RunID != 0 {
+		return p, nil
+	}
+
+	p, err := renderTemplates(p, results)
+	if err != nil {
+		return p, err
+	}
+
+	if p.CIDescription != "" {
+		if p.Description != "" {
+			p.Description += "\n\n"
+		}
+		p.Description += p.CIDescription
+	}
+
+	return p, nil
+}
+
+// renderTemplates renders the title and description templates when the template data is set
+func renderTemplates(p UploadParams, results []models.Result) (UploadParams, error) {
+	if p.TemplateData == nil {
+		return p, nil
+	}
+
+	data := *p.TemplateData
+	data.Stats = countStats(results)
+
+	title, err := tmpl.Render("title", p.Title, data)
+	if err != nil {
+		return p, err
+	}
+
+	description, err := tmpl.Render("description", p.Description, data)
+	if err != nil {
+		return p, err
+	}
+
+	p.Title = title
+	p.Description = description
+
+	return p, nil
+}
+
+// countStats counts the results by status
+func countStats(results []models.Result) tmpl.Stats {
+	stats := tmpl.Stats{Total: len(results)}
+	for _, r := range results {
+		switch strings.ToLower(r.Execution.Status) {
+		case "passed":
+			stats.Passed++
+		case "failed":
+			stats.Failed++
+		case "skipped":
+			stats.Skipped++
+		case "blocked":
+			stats.Blocked++
+		case "invalid":
+			stats.Invalid++
+		}
+	}
+	return stats
+}
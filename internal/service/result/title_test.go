@@ -0,0 +1,106 @@
+package result
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qase-tms/qasectl/internal/ci"
+	models "github.com/qase-tms/qasectl/internal/models/result"
+	"github.com/qase-tms/qasectl/internal/tmpl"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenderRunText(t *testing.T) {
+	data := tmpl.NewData(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), ci.Info{Branch: "main"})
+	results := []models.Result{
+		filterModel("Test 1", "passed", "s1", nil, nil),
+		filterModel("Test 2", "Failed", "s2", nil, nil),
+		filterModel("Test 3", "passed", "s3", nil, nil),
+	}
+
+	tests := []struct {
+		name            string
+		p               UploadParams
+		wantTitle       string
+		wantDescription string
+		wantErr         bool
+	}{
+		{
+			name:            "without template data",
+			p:               UploadParams{Title: "{{.Branch}}", Description: "{{.Date}}"},
+			wantTitle:       "{{.Branch}}",
+			wantDescription: "{{.Date}}",
+		},
+		{
+			name:            "render title and description",
+			p:               UploadParams{Title: "{{.Branch}} {{.Date}}", Description: "{{.Stats.Passed}} passed, {{.Stats.Failed}} failed", TemplateData: &data},
+			wantTitle:       "main 2026-03-14",
+			wantDescription: "2 passed, 1 failed",
+		},
+		{
+			name:            "existing run is not renamed",
+			p:               UploadParams{RunID: 1, Title: "{{.Branch}}", TemplateData: &data},
+			wantTitle:       "{{.Branch}}",
+			wantDescription: "",
+		},
+		{
+			name:            "CI description is appended after rendering",
+			p:               UploadParams{Title: "{{.Branch}}", Description: "{{.Stats.Total}} results", CIDescription: "Branch: fix/{{x}}", TemplateData: &data},
+			wantTitle:       "main",
+			wantDescription: "3 results\n\nBranch: fix/{{x}}",
+		},
+		{
+			name:            "CI description without template data",
+			p:               UploadParams{Title: "title", CIDescription: "CI: github"},
+			wantTitle:       "title",
+			wantDescription: "CI: github",
+		},
+		{
+			name:    "invalid template",
+			p:       UploadParams{Title: "{{.Unknown}}", TemplateData: &data},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderRunText(tt.p, results)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderRunText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Title != tt.wantTitle || got.Description != tt.wantDescription {
+				t.Errorf("renderRunText() = %q, %q, want %q, %q", got.Title, got.Description, tt.wantTitle, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestService_Upload_StatsAfterTransforms(t *testing.T) {
+	f := newFixture(t)
+
+	f.parser.EXPECT().Parse().Return([]models.Result{
+		filterModel("Test 1", "passed", "s1", nil, nil),
+		filterModel("Test 2", "broken", "s2", nil, nil),
+	}, nil)
+	f.rs.EXPECT().
+		CreateRun(gomock.Any(), "project", "Nightly", "1 passed, 1 failed", "", int64(0), int64(0), []string{}, false, "", nil, map[string]string(nil), []int64(nil)).
+		Return(int64(1), nil)
+	f.client.EXPECT().UploadData(gomock.Any(), "project", int64(1), gomock.Any()).Return(nil)
+	f.rs.EXPECT().CompleteRun(gomock.Any(), "project", int64(1)).Return(nil)
+
+	s := NewService(f.client, f.parser, f.rs)
+	err := s.Upload(context.Background(), UploadParams{
+		Project:      "project",
+		Title:        "Nightly",
+		Description:  "{{.Stats.Passed}} passed, {{.Stats.Failed}} failed",
+		Batch:        20,
+		Statuses:     map[string]string{"broken": "failed"},
+		TemplateData: &tmpl.Data{},
+	})
+	if err != nil {
+		t.Errorf("Service.Upload() error = %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultDeleteConcurrency is the number of runs deleted in parallel by default
+const DefaultDeleteConcurrency = 5
+
+// DeleteParams selects the runs to delete. Runs have to match all the given selectors.
+type DeleteParams struct {
+	IDs []int64
+	All bool
+	// Start and End limit the run start time, as Unix timestamps
+	Start int64
+	End   int64
+	// Title is a regular expression matched against the run title
+	Title    string
+	Statuses []string
+	Tags     []string
+	// OlderThan selects runs started earlier than this duration ago
+	OlderThan time.Duration
+}
+
+// DeleteReport holds the outcome of deleting runs
+type DeleteReport struct {
+	Deleted []int64
+	Failed  map[int64]error
+}
+
+// Err returns an error when some of the runs were not deleted
+func (r DeleteReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(r.Failed))
+	for id := range r.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, fmt.Errorf("run %d: %w", id, r.Failed[id]))
+	}
+
+	return fmt.Errorf("failed to delete %d of %d test runs: %w", len(r.Failed), len(r.Failed)+len(r.Deleted), errors.Join(errs...))
+}
+
+// SelectRuns returns the runs matching the params
+func (s *Service) SelectRuns(ctx context.Context, projectCode string, p DeleteParams) ([]run.Run, error) {
+	hasSelectors := p.Title != "" || len(p.Statuses) > 0 || len(p.Tags) > 0 || p.OlderThan > 0
+	if len(p.IDs) == 0 && !p.All && !hasSelectors {
+		return nil, fmt.Errorf("no ids provided")
+	}
+
+	var title *regexp.Regexp
+	if p.Title != "" {
+		re, err := regexp.Compile(p.Title)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern: %w", err)
+		}
+		title = re
+	}
+
+	found, err := s.ListRuns(ctx, projectCode, run.Filter{
+		Status:        strings.Join(p.Statuses, ","),
+		FromStartTime: p.Start,
+		ToStartTime:   p.End,
+		Tags:          p.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var before time.Time
+	if p.OlderThan > 0 {
+		before = time.Now().Add(-p.OlderThan)
+	}
+
+	selected := make([]run.Run, 0, len(found))
+	for _, r := range found {
+		if len(p.IDs) > 0 && !slices.Contains(p.IDs, r.ID) {
+			continue
+		}
+		if title != nil && !title.MatchString(r.Title) {
+			continue
+		}
+		if !before.IsZero() && (r.StartTime == nil || !r.StartTime.Before(before)) {
+			continue
+		}
+		selected = append(selected, r)
+	}
+
+	return selected, nil
+}
+
+// DeleteRuns deletes the runs with at most concurrency requests in parallel.
+// Failures do not stop the deletion and are collected in the report.
+func (s *Service) DeleteRuns(ctx context.Context, projectCode string, runs []run.Run, concurrency int) DeleteReport {
+	const op = "run.deleteruns"
+	logger := slog.With("op", op)
+
+	if concurrency < 1 {
+		concurrency = DefaultDeleteConcurrency
+	}
+
+	report := DeleteReport{
+		Deleted: make([]int64, 0, len(runs)),
+		Failed:  make(map[int64]error),
+	}
+
+	var (
+		mu sync.Mutex
+		g  errgroup.Group
+	)
+	g.SetLimit(concurrency)
+
+	for _, r := range runs {
+		g.Go(func() error {
+			err := s.client.DeleteTestRun(ctx, projectCode, r.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				logger.Error("failed to delete run", "id", r.ID, "error", err)
+				report.Failed[r.ID] = err
+				return nil
+			}
+
+			logger.Debug("deleted run", "id", r.ID)
+			report.Deleted = append(report.Deleted, r.ID)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+	slices.Sort(report.Deleted)
+
+	return report
+}
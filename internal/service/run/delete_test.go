@@ -0,0 +1,123 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_SelectRuns(t *testing.T) {
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+
+	runs := []run.Run{
+		{ID: 1, Title: "Nightly 2024-01-01", StartTime: &old},
+		{ID: 2, Title: "Nightly 2024-03-01", StartTime: &recent},
+		{ID: 3, Title: "Smoke", StartTime: &old},
+		{ID: 4, Title: "Nightly draft"},
+	}
+
+	tests := []struct {
+		name       string
+		p          DeleteParams
+		filter     run.Filter
+		findErr    error
+		want       []int64
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "by ids",
+			p:    DeleteParams{IDs: []int64{2, 3, 9}, Start: 100, End: 200},
+			filter: run.Filter{
+				FromStartTime: 100,
+				ToStartTime:   200,
+			},
+			want: []int64{2, 3},
+		},
+		{
+			name: "all",
+			p:    DeleteParams{All: true},
+			want: []int64{1, 2, 3, 4},
+		},
+		{
+			name:   "by title and status",
+			p:      DeleteParams{Title: "^Nightly \\d", Statuses: []string{"complete", "abort"}},
+			filter: run.Filter{Status: "complete,abort"},
+			want:   []int64{1, 2},
+		},
+		{
+			name: "older than",
+			p:    DeleteParams{OlderThan: 30 * 24 * time.Hour},
+			want: []int64{1, 3},
+		},
+		{
+			name:       "no selectors",
+			p:          DeleteParams{},
+			wantErr:    true,
+			errMessage: "no ids provided",
+		},
+		{
+			name:       "invalid title pattern",
+			p:          DeleteParams{Title: "("},
+			wantErr:    true,
+			errMessage: "invalid title pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:       "failed to get runs",
+			p:          DeleteParams{All: true},
+			findErr:    errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get test runs: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.findErr != nil || !tt.wantErr {
+				f.client.EXPECT().FindRuns(gomock.Any(), "project", tt.filter).Return(runs, tt.findErr)
+			}
+
+			s := NewService(f.client)
+			got, err := s.SelectRuns(context.Background(), "project", tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectRuns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+
+			ids := make([]int64, 0, len(got))
+			for _, r := range got {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, ids, tt.want)
+		})
+	}
+}
+
+func TestService_DeleteRuns(t *testing.T) {
+	f := newFixture(t)
+
+	f.client.EXPECT().DeleteTestRun(gomock.Any(), "project", int64(1)).Return(nil)
+	f.client.EXPECT().DeleteTestRun(gomock.Any(), "project", int64(2)).Return(errors.New("forbidden"))
+	f.client.EXPECT().DeleteTestRun(gomock.Any(), "project", int64(3)).Return(nil)
+	f.client.EXPECT().DeleteTestRun(gomock.Any(), "project", int64(4)).Return(errors.New("not found"))
+
+	s := NewService(f.client)
+	report := s.DeleteRuns(context.Background(), "project", []run.Run{{ID: 3}, {ID: 2}, {ID: 1}, {ID: 4}}, 2)
+
+	assert.Equal(t, report.Deleted, []int64{1, 3})
+	assert.Equal(t, len(report.Failed), 2)
+	assert.Equal(t, report.Err().Error(), "failed to delete 2 of 4 test runs: run 2: forbidden\nrun 4: not found")
+
+	if err := (DeleteReport{Deleted: []int64{1}}).Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
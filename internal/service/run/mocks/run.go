@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	custom "github.com/qase-tms/qasectl/internal/models/fields/custom"
 	run "github.com/qase-tms/qasectl/internal/models/run"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +41,20 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 	return m.recorder
 }
 
+// AbortRun mocks base method.
+func (m *Mockclient) AbortRun(ctx context.Context, projectCode string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortRun", ctx, projectCode, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortRun indicates an expected call of AbortRun.
+func (mr *MockclientMockRecorder) AbortRun(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortRun", reflect.TypeOf((*Mockclient)(nil).AbortRun), ctx, projectCode, id)
+}
+
 // CompleteRun mocks base method.
 func (m *Mockclient) CompleteRun(ctx context.Context, projectCode string, runId int64) error {
 	m.ctrl.T.Helper()
@@ -55,18 +70,33 @@ func (mr *MockclientMockRecorder) CompleteRun(ctx, projectCode, runId any) *gomo
 }
 
 // CreateRun mocks base method.
-func (m *Mockclient) CreateRun(ctx context.Context, projectCode, title, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error) {
+func (m *Mockclient) CreateRun(ctx context.Context, projectCode, title, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRun", ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime)
+	ret := m.ctrl.Call(m, "CreateRun", ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime, customFields, configurations)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRun indicates an expected call of CreateRun.
-func (mr *MockclientMockRecorder) CreateRun(ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime any) *gomock.Call {
+func (mr *MockclientMockRecorder) CreateRun(ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime, customFields, configurations any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*Mockclient)(nil).CreateRun), ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*Mockclient)(nil).CreateRun), ctx, projectCode, title, description, envSlug, mileID, planID, tags, isCloud, browser, startTime, customFields, configurations)
+}
+
+// CreateRunFrom mocks base method.
+func (m *Mockclient) CreateRunFrom(ctx context.Context, projectCode string, src run.Run) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRunFrom", ctx, projectCode, src)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRunFrom indicates an expected call of CreateRunFrom.
+func (mr *MockclientMockRecorder) CreateRunFrom(ctx, projectCode, src any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRunFrom", reflect.TypeOf((*Mockclient)(nil).CreateRunFrom), ctx, projectCode, src)
 }
 
 // DeleteTestRun mocks base method.
@@ -83,17 +113,91 @@ func (mr *MockclientMockRecorder) DeleteTestRun(ctx, projectCode, id any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTestRun", reflect.TypeOf((*Mockclient)(nil).DeleteTestRun), ctx, projectCode, id)
 }
 
-// GetTestRuns mocks base method.
-func (m *Mockclient) GetTestRuns(ctx context.Context, projectCode string, start, end int64) ([]run.Run, error) {
+// FindRuns mocks base method.
+func (m *Mockclient) FindRuns(ctx context.Context, projectCode string, f run.Filter) ([]run.Run, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTestRuns", ctx, projectCode, start, end)
+	ret := m.ctrl.Call(m, "FindRuns", ctx, projectCode, f)
 	ret0, _ := ret[0].([]run.Run)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTestRuns indicates an expected call of GetTestRuns.
-func (mr *MockclientMockRecorder) GetTestRuns(ctx, projectCode, start, end any) *gomock.Call {
+// FindRuns indicates an expected call of FindRuns.
+func (mr *MockclientMockRecorder) FindRuns(ctx, projectCode, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuns", reflect.TypeOf((*Mockclient)(nil).FindRuns), ctx, projectCode, f)
+}
+
+// GetConfigurations mocks base method.
+func (m *Mockclient) GetConfigurations(ctx context.Context, projectCode string) ([]run.ConfigurationGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigurations", ctx, projectCode)
+	ret0, _ := ret[0].([]run.ConfigurationGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigurations indicates an expected call of GetConfigurations.
+func (mr *MockclientMockRecorder) GetConfigurations(ctx, projectCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurations", reflect.TypeOf((*Mockclient)(nil).GetConfigurations), ctx, projectCode)
+}
+
+// GetCustomFields mocks base method.
+func (m *Mockclient) GetCustomFields(ctx context.Context) ([]custom.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFields", ctx)
+	ret0, _ := ret[0].([]custom.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFields indicates an expected call of GetCustomFields.
+func (mr *MockclientMockRecorder) GetCustomFields(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFields", reflect.TypeOf((*Mockclient)(nil).GetCustomFields), ctx)
+}
+
+// GetRun mocks base method.
+func (m *Mockclient) GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, projectCode, id)
+	ret0, _ := ret[0].(run.Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockclientMockRecorder) GetRun(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*Mockclient)(nil).GetRun), ctx, projectCode, id)
+}
+
+// GetRunWithCases mocks base method.
+func (m *Mockclient) GetRunWithCases(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunWithCases", ctx, projectCode, id)
+	ret0, _ := ret[0].(run.Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunWithCases indicates an expected call of GetRunWithCases.
+func (mr *MockclientMockRecorder) GetRunWithCases(ctx, projectCode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunWithCases", reflect.TypeOf((*Mockclient)(nil).GetRunWithCases), ctx, projectCode, id)
+}
+
+// UpdateRun mocks base method.
+func (m *Mockclient) UpdateRun(ctx context.Context, projectCode string, id int64, u run.Update) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, projectCode, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockclientMockRecorder) UpdateRun(ctx, projectCode, id, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestRuns", reflect.TypeOf((*Mockclient)(nil).GetTestRuns), ctx, projectCode, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*Mockclient)(nil).UpdateRun), ctx, projectCode, id, u)
 }
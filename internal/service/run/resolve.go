@@ -0,0 +1,116 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+	"github.com/qase-tms/qasectl/internal/models/run"
+)
+
+// ResolveCustomFields returns the custom field values keyed by field ID.
+// Keys that are numbers are used as IDs, other keys are matched against the titles of the run custom fields
+// enabled for the project.
+func (s *Service) ResolveCustomFields(ctx context.Context, projectCode string, values map[string]string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	resolved := make(map[string]string, len(values))
+	titles := make([]string, 0)
+	for key, v := range values {
+		if _, err := strconv.ParseInt(key, 10, 64); err == nil {
+			resolved[key] = v
+			continue
+		}
+		titles = append(titles, key)
+	}
+
+	if len(titles) == 0 {
+		return resolved, nil
+	}
+
+	fields, err := s.client.GetCustomFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	slices.Sort(titles)
+	for _, title := range titles {
+		ids := make([]int64, 0, 1)
+		for _, field := range fields {
+			if isRunField(field, projectCode) && strings.EqualFold(field.Title, title) {
+				ids = append(ids, field.ID)
+			}
+		}
+
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("run custom field %q not found in project %s", title, projectCode)
+		case 1:
+			resolved[strconv.FormatInt(ids[0], 10)] = values[title]
+		default:
+			return nil, fmt.Errorf("custom field title %q is used by fields %v, pass the field ID instead", title, ids)
+		}
+	}
+
+	return resolved, nil
+}
+
+// isRunField reports whether the custom field belongs to runs and is enabled for the project
+func isRunField(f custom.CustomField, projectCode string) bool {
+	if f.Entity != "run" {
+		return false
+	}
+
+	return f.AllProjects || slices.ContainsFunc(f.Projects, func(code string) bool {
+		return strings.EqualFold(code, projectCode)
+	})
+}
+
+// ResolveConfigurations returns the IDs of the configurations given as group title to configuration title
+func (s *Service) ResolveConfigurations(ctx context.Context, projectCode string, values map[string]string) ([]int64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	groups, err := s.client.GetConfigurations(ctx, projectCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configurations: %w", err)
+	}
+
+	ids := make([]int64, 0, len(values))
+	for group, value := range values {
+		i := slices.IndexFunc(groups, func(g run.ConfigurationGroup) bool {
+			return strings.EqualFold(g.Title, group)
+		})
+		if i == -1 {
+			allowed := make([]string, 0, len(groups))
+			for _, g := range groups {
+				allowed = append(allowed, g.Title)
+			}
+			return nil, fmt.Errorf("configuration group %q not found, allowed groups: %s", group, strings.Join(allowed, ", "))
+		}
+
+		confs := groups[i].Configurations
+		j := slices.IndexFunc(confs, func(c run.Configuration) bool {
+			return strings.EqualFold(c.Title, value)
+		})
+		if j == -1 {
+			allowed := make([]string, 0, len(confs))
+			for _, c := range confs {
+				allowed = append(allowed, c.Title)
+			}
+			return nil, fmt.Errorf("configuration %q not found in group %q, allowed configurations: %s", value, groups[i].Title, strings.Join(allowed, ", "))
+		}
+
+		ids = append(ids, confs[j].ID)
+	}
+
+	slices.Sort(ids)
+
+	return ids, nil
+}
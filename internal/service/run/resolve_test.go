@@ -0,0 +1,151 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ResolveCustomFields(t *testing.T) {
+	fields := []custom.CustomField{
+		{ID: 3, Title: "Release", Entity: "run", AllProjects: true},
+		{ID: 4, Title: "Build number", Entity: "run", Projects: []string{"PRJ"}},
+		{ID: 5, Title: "Layer", Entity: "run", AllProjects: true},
+		{ID: 6, Title: "Layer", Entity: "run", Projects: []string{"prj"}},
+		{ID: 7, Title: "Release", Entity: "case", AllProjects: true},
+		{ID: 8, Title: "Build number", Entity: "run", Projects: []string{"DEMO"}},
+		{ID: 9, Title: "Sprint", Entity: "run", Projects: []string{"DEMO"}},
+	}
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		isUsed     bool
+		err        error
+		want       map[string]string
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:   "ids only",
+			values: map[string]string{"3": "1.2.0"},
+			want:   map[string]string{"3": "1.2.0"},
+		},
+		{
+			name:   "titles",
+			values: map[string]string{"release": "1.2.0", "Build number": "77", "9": "x"},
+			isUsed: true,
+			want:   map[string]string{"3": "1.2.0", "4": "77", "9": "x"},
+		},
+		{
+			name:       "unknown title",
+			values:     map[string]string{"Sprint": "12"},
+			isUsed:     true,
+			wantErr:    true,
+			errMessage: "run custom field \"Sprint\" not found in project PRJ",
+		},
+		{
+			name:   "fields of other entities and projects are ignored",
+			values: map[string]string{"Release": "1.2.0", "build number": "77"},
+			isUsed: true,
+			want:   map[string]string{"3": "1.2.0", "4": "77"},
+		},
+		{
+			name:       "ambiguous title",
+			values:     map[string]string{"Layer": "e2e"},
+			isUsed:     true,
+			wantErr:    true,
+			errMessage: "custom field title \"Layer\" is used by fields [5 6], pass the field ID instead",
+		},
+		{
+			name:       "failed to get custom fields",
+			values:     map[string]string{"Release": "1.2.0"},
+			isUsed:     true,
+			err:        errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to get custom fields: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.isUsed {
+				f.client.EXPECT().GetCustomFields(gomock.Any()).Return(fields, tt.err)
+			}
+
+			s := NewService(f.client)
+			got, err := s.ResolveCustomFields(context.Background(), "PRJ", tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveCustomFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ResolveConfigurations(t *testing.T) {
+	groups := []run.ConfigurationGroup{
+		{ID: 1, Title: "OS", Configurations: []run.Configuration{{ID: 10, Title: "Linux"}, {ID: 11, Title: "Windows"}}},
+		{ID: 2, Title: "Browser", Configurations: []run.Configuration{{ID: 20, Title: "Chrome"}, {ID: 21, Title: "Firefox"}}},
+	}
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		want       []int64
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:   "resolved",
+			values: map[string]string{"browser": "firefox", "OS": "Linux"},
+			want:   []int64{10, 21},
+		},
+		{
+			name:       "unknown group",
+			values:     map[string]string{"Device": "Pixel"},
+			wantErr:    true,
+			errMessage: "configuration group \"Device\" not found, allowed groups: OS, Browser",
+		},
+		{
+			name:       "unknown configuration",
+			values:     map[string]string{"OS": "macOS"},
+			wantErr:    true,
+			errMessage: "configuration \"macOS\" not found in group \"OS\", allowed configurations: Linux, Windows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.client.EXPECT().GetConfigurations(gomock.Any(), "project").Return(groups, nil)
+
+			s := NewService(f.client)
+			got, err := s.ResolveConfigurations(context.Background(), "project", tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveConfigurations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+			assert.Equal(t, got, tt.want)
+		})
+	}
+
+	s := NewService(newFixture(t).client)
+	if got, err := s.ResolveConfigurations(context.Background(), "project", nil); got != nil || err != nil {
+		t.Errorf("ResolveConfigurations() = %v, %v, want nil, nil", got, err)
+	}
+}
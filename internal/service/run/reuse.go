@@ -0,0 +1,190 @@
+package run
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/qase-tms/qasectl/internal/models/run"
+)
+
+const (
+	// lockRetryInterval is the delay between attempts to take the local lock
+	lockRetryInterval = 200 * time.Millisecond
+	// lockTimeout is the time after which a lock left by a crashed process is considered stale
+	lockTimeout = 2 * time.Minute
+)
+
+// DefaultStateDir is the directory for the local locks of ReuseRun
+func DefaultStateDir() string {
+	return filepath.Join(os.TempDir(), "qasectl")
+}
+
+// ReuseRun returns the ID of an active run with the title, or with the tag when it is set.
+// When there is no such run, it is created with create. The lock file only serializes shards on the
+// same machine. Duplicates are resolved from the runs in Qase, independently of the lock: after creating
+// a run, every shard looks up the active runs again, keeps the one with the lowest ID and deletes its own.
+// The flag reports whether the returned run was created.
+func (s *Service) ReuseRun(ctx context.Context, projectCode, title, tag string, create func(ctx context.Context) (int64, error)) (int64, bool, error) {
+	const op = "run.reuserun"
+	logger := slog.With("op", op)
+
+	unlock, err := s.lock(ctx, projectCode, title, tag)
+	if err != nil {
+		return 0, false, err
+	}
+	defer unlock()
+
+	id, err := s.findActiveRun(ctx, projectCode, title, tag)
+	if err != nil {
+		return 0, false, err
+	}
+	if id != 0 {
+		logger.Info("reusing active run", "id", id)
+		return id, false, nil
+	}
+
+	id, err = create(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	winner, err := s.findActiveRun(ctx, projectCode, title, tag)
+	if err != nil {
+		return 0, false, err
+	}
+	if winner == 0 || winner >= id {
+		return id, true, nil
+	}
+
+	logger.Info("another job created the run first, deleting the duplicate", "id", id, "reusedID", winner)
+	if err := s.client.DeleteTestRun(ctx, projectCode, id); err != nil {
+		return 0, false, fmt.Errorf("failed to delete duplicate run %d: %w", id, err)
+	}
+
+	return winner, false, nil
+}
+
+// CompleteRunWhenAll records that one of n jobs has finished and completes the run when the last one arrives.
+// Arrivals are counted with marker files in stateDir, which has to be shared by all jobs, e.g. a volume mounted
+// on every CI runner. Qase has no state to count them, so a directory local to each runner never reaches n.
+// It reports whether the run was completed.
+func (s *Service) CompleteRunWhenAll(ctx context.Context, projectCode string, runID int64, n int, stateDir string) (bool, error) {
+	const op = "run.completerunwhenall"
+	logger := slog.With("op", op)
+
+	if n < 1 {
+		return false, fmt.Errorf("invalid number of jobs %d, expected at least 1", n)
+	}
+
+	if stateDir == "" {
+		return false, fmt.Errorf("state directory shared by all jobs is required")
+	}
+	dir := filepath.Join(stateDir, fmt.Sprintf("complete-%s-%d", projectCode, runID))
+
+	arrival, err := arrive(dir, n)
+	if err != nil {
+		return false, fmt.Errorf("failed to record job completion: %w", err)
+	}
+
+	if arrival < n {
+		logger.Info("waiting for other jobs to finish", "finished", arrival, "total", n)
+		return false, nil
+	}
+
+	if err := s.client.CompleteRun(ctx, projectCode, runID); err != nil {
+		return false, err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warn("failed to remove completion markers", "dir", dir, "error", err)
+	}
+
+	return true, nil
+}
+
+// findActiveRun returns the lowest ID of the active runs with the title or the tag, or 0 when there is none
+func (s *Service) findActiveRun(ctx context.Context, projectCode, title, tag string) (int64, error) {
+	f := run.Filter{Status: "active"}
+	if tag == "" {
+		f.Search = title
+	}
+
+	runs, err := s.client.FindRuns(ctx, projectCode, f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find active runs: %w", err)
+	}
+
+	var id int64
+	for _, r := range runs {
+		matched := r.Title == title
+		if tag != "" {
+			matched = slices.Contains(r.Tags, tag)
+		}
+		if matched && (id == 0 || r.ID < id) {
+			id = r.ID
+		}
+	}
+
+	return id, nil
+}
+
+// lock takes a lock file for the run key and returns the function releasing it
+func (s *Service) lock(ctx context.Context, projectCode, title, tag string) (func(), error) {
+	if err := os.MkdirAll(s.stateDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	sum := sha1.Sum([]byte(projectCode + "\x00" + title + "\x00" + tag))
+	path := filepath.Join(s.stateDir, "run-"+hex.EncodeToString(sum[:8])+".lock")
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to take run lock: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockTimeout {
+			slog.Warn("removing stale run lock", "path", path)
+			_ = os.Remove(path)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// arrive creates the next free marker in dir and returns its number, starting from 1
+func arrive(dir string, n int) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	for i := 1; i <= n; i++ {
+		f, err := os.OpenFile(filepath.Join(dir, strconv.Itoa(i)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			return i, f.Close()
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("all %d jobs have already finished", n)
+}
@@ -0,0 +1,169 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qase-tms/qasectl/internal/models/run"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ReuseRun(t *testing.T) {
+	active := run.Filter{Status: "active"}
+	byTitle := run.Filter{Status: "active", Search: "Nightly"}
+
+	tests := []struct {
+		name        string
+		tag         string
+		filter      run.Filter
+		before      []run.Run
+		after       []run.Run
+		createdID   int64
+		deleteErr   error
+		wantID      int64
+		wantCreated bool
+		wantDelete  bool
+		wantErr     bool
+		errMessage  string
+	}{
+		{
+			name:   "reuse run with the same title",
+			filter: byTitle,
+			before: []run.Run{{ID: 7, Title: "Nightly (old)"}, {ID: 9, Title: "Nightly"}, {ID: 8, Title: "Nightly"}},
+			wantID: 8,
+		},
+		{
+			name:   "reuse run with the tag",
+			tag:    "pipeline-77",
+			filter: active,
+			before: []run.Run{{ID: 3, Title: "Nightly"}, {ID: 4, Title: "Shard 2", Tags: []string{"pipeline-77"}}},
+			wantID: 4,
+		},
+		{
+			name:        "create run",
+			filter:      byTitle,
+			after:       []run.Run{{ID: 10, Title: "Nightly"}},
+			createdID:   10,
+			wantID:      10,
+			wantCreated: true,
+		},
+		{
+			name:       "another job created the run first",
+			filter:     byTitle,
+			after:      []run.Run{{ID: 11, Title: "Nightly"}, {ID: 10, Title: "Nightly"}},
+			createdID:  11,
+			wantID:     10,
+			wantDelete: true,
+		},
+		{
+			name:       "failed to delete duplicate",
+			filter:     byTitle,
+			after:      []run.Run{{ID: 11, Title: "Nightly"}, {ID: 10, Title: "Nightly"}},
+			createdID:  11,
+			deleteErr:  errors.New("forbidden"),
+			wantDelete: true,
+			wantErr:    true,
+			errMessage: "failed to delete duplicate run 11: forbidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			f.client.EXPECT().FindRuns(gomock.Any(), "project", tt.filter).Return(tt.before, nil)
+			if tt.createdID != 0 {
+				f.client.EXPECT().FindRuns(gomock.Any(), "project", tt.filter).Return(tt.after, nil)
+			}
+			if tt.wantDelete {
+				f.client.EXPECT().DeleteTestRun(gomock.Any(), "project", tt.createdID).Return(tt.deleteErr)
+			}
+
+			s := NewService(f.client)
+			s.stateDir = t.TempDir()
+
+			calls := 0
+			create := func(ctx context.Context) (int64, error) {
+				calls++
+				return tt.createdID, nil
+			}
+
+			id, created, err := s.ReuseRun(context.Background(), "project", "Nightly", tt.tag, create)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReuseRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.errMessage {
+				t.Errorf("ReuseRun() error = %v, want %v", err, tt.errMessage)
+			}
+			if id != tt.wantID || created != tt.wantCreated {
+				t.Errorf("ReuseRun() = %d, %v, want %d, %v", id, created, tt.wantID, tt.wantCreated)
+			}
+			wantCalls := 0
+			if tt.createdID != 0 {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("create called %d times, want %d", calls, wantCalls)
+			}
+
+			entries, _ := os.ReadDir(s.stateDir)
+			if len(entries) != 0 {
+				t.Errorf("lock was not released: %v", entries)
+			}
+		})
+	}
+}
+
+func TestService_ReuseRun_Lock(t *testing.T) {
+	f := newFixture(t)
+
+	s := NewService(f.client)
+	s.stateDir = t.TempDir()
+
+	unlock, err := s.lock(context.Background(), "project", "Nightly", "")
+	if err != nil {
+		t.Fatalf("lock() unexpected error: %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = s.ReuseRun(ctx, "project", "Nightly", "", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ReuseRun() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestService_CompleteRunWhenAll(t *testing.T) {
+	f := newFixture(t)
+	dir := t.TempDir()
+
+	f.client.EXPECT().CompleteRun(gomock.Any(), "project", int64(5)).Return(nil)
+
+	s := NewService(f.client)
+
+	for i, want := range []bool{false, false, true} {
+		completed, err := s.CompleteRunWhenAll(context.Background(), "project", 5, 3, dir)
+		if err != nil {
+			t.Fatalf("job %d: CompleteRunWhenAll() unexpected error: %v", i+1, err)
+		}
+		if completed != want {
+			t.Errorf("job %d: CompleteRunWhenAll() = %v, want %v", i+1, completed, want)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "complete-project-5")); !os.IsNotExist(err) {
+		t.Errorf("completion markers were not removed: %v", err)
+	}
+
+	if _, err := s.CompleteRunWhenAll(context.Background(), "project", 5, 0, dir); err == nil || err.Error() != "invalid number of jobs 0, expected at least 1" {
+		t.Errorf("CompleteRunWhenAll() error = %v, want invalid number of jobs", err)
+	}
+
+	if _, err := s.CompleteRunWhenAll(context.Background(), "project", 5, 3, ""); err == nil || err.Error() != "state directory shared by all jobs is required" {
+		t.Errorf("CompleteRunWhenAll() error = %v, want state directory is required", err)
+	}
+}
@@ -3,7 +3,9 @@ package run
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/qase-tms/qasectl/internal/models/fields/custom"
 	"github.com/qase-tms/qasectl/internal/models/run"
 )
 
@@ -11,25 +13,33 @@ import (
 //
 //go:generate mockgen -source=$GOFILE -destination=$PWD/mocks/${GOFILE} -package=mocks
 type client interface {
-	CreateRun(ctx context.Context, projectCode, title string, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error)
+	CreateRun(ctx context.Context, projectCode, title string, description, envSlug string, mileID, planID int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error)
 	CompleteRun(ctx context.Context, projectCode string, runId int64) error
-	GetTestRuns(ctx context.Context, projectCode string, start, end int64) ([]run.Run, error)
+	GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error)
+	GetRunWithCases(ctx context.Context, projectCode string, id int64) (run.Run, error)
+	FindRuns(ctx context.Context, projectCode string, f run.Filter) ([]run.Run, error)
+	CreateRunFrom(ctx context.Context, projectCode string, src run.Run) (int64, error)
+	UpdateRun(ctx context.Context, projectCode string, id int64, u run.Update) error
+	AbortRun(ctx context.Context, projectCode string, id int64) error
+	GetCustomFields(ctx context.Context) ([]custom.CustomField, error)
+	GetConfigurations(ctx context.Context, projectCode string) ([]run.ConfigurationGroup, error)
 	DeleteTestRun(ctx context.Context, projectCode string, id int64) error
 }
 
 // Service is a Service for run
 type Service struct {
-	client client
+	client   client
+	stateDir string
 }
 
 // NewService creates a new run Service
 func NewService(client client) *Service {
-	return &Service{client: client}
+	return &Service{client: client, stateDir: DefaultStateDir()}
 }
 
 // CreateRun creates a new run
-func (s *Service) CreateRun(ctx context.Context, pc, t, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64) (int64, error) {
-	return s.client.CreateRun(ctx, pc, t, d, e, m, plan, tags, isCloud, browser, startTime)
+func (s *Service) CreateRun(ctx context.Context, pc, t, d, e string, m, plan int64, tags []string, isCloud bool, browser string, startTime *int64, customFields map[string]string, configurations []int64) (int64, error) {
+	return s.client.CreateRun(ctx, pc, t, d, e, m, plan, tags, isCloud, browser, startTime, customFields, configurations)
 }
 
 // CompleteRun completes a run
@@ -37,40 +47,72 @@ func (s *Service) CompleteRun(ctx context.Context, projectCode string, runId int
 	return s.client.CompleteRun(ctx, projectCode, runId)
 }
 
-func (s *Service) DeleteRun(ctx context.Context, projectCode string, ids []int64, all bool, start, end int64) error {
-	if len(ids) == 0 && !all {
-		return fmt.Errorf("no ids provided")
+// UpdateRun updates a run
+func (s *Service) UpdateRun(ctx context.Context, projectCode string, id int64, u run.Update) error {
+	if u.IsEmpty() {
+		return fmt.Errorf("nothing to update")
 	}
 
-	foundIDs, err := s.client.GetTestRuns(ctx, projectCode, start, end)
+	return s.client.UpdateRun(ctx, projectCode, id, u)
+}
+
+// AbortRun aborts a run
+func (s *Service) AbortRun(ctx context.Context, projectCode string, id int64) error {
+	return s.client.AbortRun(ctx, projectCode, id)
+}
+
+// CloneRun creates a new run with the cases, plan, environment, milestone and configurations of the run.
+// An empty title keeps the title of the cloned run.
+func (s *Service) CloneRun(ctx context.Context, projectCode string, id int64, title string) (int64, error) {
+	src, err := s.client.GetRunWithCases(ctx, projectCode, id)
 	if err != nil {
-		return fmt.Errorf("failed to get test runs: %w", err)
+		return 0, fmt.Errorf("failed to get run %d: %w", id, err)
 	}
 
-	delIDs := make([]int64, 0)
+	if title != "" {
+		src.Title = title
+	}
 
-	if len(ids) > 0 {
-		for _, v := range foundIDs {
-			for _, id := range ids {
-				if v.ID == id {
-					delIDs = append(delIDs, id)
-				}
-			}
-		}
+	newID, err := s.client.CreateRunFrom(ctx, projectCode, src)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clone run %d: %w", id, err)
 	}
 
-	if all {
-		for _, v := range foundIDs {
-			delIDs = append(delIDs, v.ID)
-		}
+	return newID, nil
+}
+
+// ListRuns returns the runs matching the filter
+func (s *Service) ListRuns(ctx context.Context, projectCode string, f run.Filter) ([]run.Run, error) {
+	runs, err := s.client.FindRuns(ctx, projectCode, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test runs: %w", err)
+	}
+
+	if len(f.Tags) == 0 {
+		return runs, nil
 	}
 
-	for _, id := range delIDs {
-		err := s.client.DeleteTestRun(ctx, projectCode, id)
-		if err != nil {
-			return fmt.Errorf("failed to delete run with id %d: %w", id, err)
+	matched := make([]run.Run, 0, len(runs))
+	for _, r := range runs {
+		if hasTags(r, f.Tags) {
+			matched = append(matched, r)
 		}
 	}
 
-	return nil
+	return matched, nil
+}
+
+// GetRun returns a run by ID
+func (s *Service) GetRun(ctx context.Context, projectCode string, id int64) (run.Run, error) {
+	return s.client.GetRun(ctx, projectCode, id)
+}
+
+// hasTags reports whether the run has all the tags
+func hasTags(r run.Run, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(r.Tags, tag) {
+			return false
+		}
+	}
+	return true
 }
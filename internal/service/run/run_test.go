@@ -66,16 +66,18 @@ func TestService_CompleteRun(t *testing.T) {
 
 func TestService_CreateRun(t *testing.T) {
 	type args struct {
-		pc      string
-		t       string
-		d       string
-		e       string
-		m       int64
-		plan    int64
-		tags    []string
-		isCloud bool
-		browser string
-		args    baseArgs
+		pc           string
+		t            string
+		d            string
+		e            string
+		m            int64
+		plan         int64
+		tags         []string
+		isCloud      bool
+		browser      string
+		customFields map[string]string
+		configs      []int64
+		args         baseArgs
 	}
 	tests := []struct {
 		name       string
@@ -84,6 +86,23 @@ func TestService_CreateRun(t *testing.T) {
 		wantErr    bool
 		errMessage string
 	}{
+		{
+			name: "success with custom fields and configurations",
+			args: args{
+				pc:           "test",
+				t:            "test",
+				tags:         []string{"main"},
+				customFields: map[string]string{"1": "main"},
+				configs:      []int64{4, 7},
+				args: baseArgs{
+					err:    nil,
+					isUsed: true,
+				},
+			},
+			want:       1,
+			wantErr:    false,
+			errMessage: "",
+		},
 		{
 			name: "success",
 			args: args{
@@ -186,6 +205,8 @@ func TestService_CreateRun(t *testing.T) {
 					tt.args.isCloud,
 					tt.args.browser,
 					gomock.Any(), // startTime
+					tt.args.customFields,
+					tt.args.configs,
 				).
 					Return(tt.want, tt.args.args.err)
 			}
@@ -204,6 +225,8 @@ func TestService_CreateRun(t *testing.T) {
 				tt.args.isCloud,
 				tt.args.browser,
 				nil, // startTime
+				tt.args.customFields,
+				tt.args.configs,
 			)
 			if err != nil {
 				if !tt.wantErr {
@@ -220,170 +243,191 @@ func TestService_CreateRun(t *testing.T) {
 	}
 }
 
-func TestService_DeleteRun(t *testing.T) {
-	type args struct {
-		projectCode string
-		ids         []int64
-		all         bool
-		start       int64
-		end         int64
-	}
-	type argsGetTr struct {
-		models []run.Run
-		isUsed bool
-		err    error
-	}
-	type argsDelTr struct {
-		ids    []int64
-		isUsed bool
-		err    error
+func TestService_ListRuns(t *testing.T) {
+	runs := []run.Run{
+		{ID: 1, Title: "Nightly", Tags: []string{"nightly", "web"}},
+		{ID: 2, Title: "Smoke", Tags: []string{"smoke", "web"}},
+		{ID: 3, Title: "Nightly API", Tags: []string{"nightly", "api"}},
 	}
+
 	tests := []struct {
 		name       string
-		args       args
-		argsGetTr  argsGetTr
-		argsDelTr  argsDelTr
+		filter     run.Filter
+		err        error
+		want       []int64
 		wantErr    bool
 		errMessage string
 	}{
 		{
-			name: "success with ids",
-			args: args{
-				projectCode: "test",
-				ids:         []int64{1},
-				all:         false,
-				start:       0,
-				end:         0,
-			},
-			argsGetTr: argsGetTr{
-				models: []run.Run{{
-					ID: 1,
-				}},
-				isUsed: true,
-				err:    nil,
-			},
-			argsDelTr: argsDelTr{
-				ids:    []int64{1},
-				isUsed: true,
-				err:    nil,
-			},
-			wantErr:    false,
-			errMessage: "",
+			name:   "without tags",
+			filter: run.Filter{Status: "active"},
+			want:   []int64{1, 2, 3},
 		},
 		{
-			name: "success with all",
-			args: args{
-				projectCode: "test",
-				ids:         []int64{},
-				all:         true,
-				start:       0,
-				end:         0,
-			},
-			argsGetTr: argsGetTr{
-				models: []run.Run{{
-					ID: 1,
-				}},
-				isUsed: true,
-				err:    nil,
-			},
-			argsDelTr: argsDelTr{
-				ids:    []int64{1},
-				isUsed: true,
-				err:    nil,
-			},
-			wantErr:    false,
-			errMessage: "",
+			name:   "with all tags",
+			filter: run.Filter{Tags: []string{"nightly", "web"}},
+			want:   []int64{1},
 		},
 		{
-			name: "incorrect args",
-			args: args{
-				projectCode: "test",
-				ids:         []int64{},
-				all:         false,
-				start:       0,
-				end:         0,
-			},
-			argsGetTr: argsGetTr{
-				models: []run.Run{{}},
-				isUsed: false,
-				err:    nil,
-			},
-			argsDelTr: argsDelTr{
-				ids:    []int64{1},
-				isUsed: false,
-				err:    nil,
-			},
+			name:       "failed to get runs",
+			filter:     run.Filter{},
+			err:        errors.New("error"),
 			wantErr:    true,
-			errMessage: "no ids provided",
+			errMessage: "failed to get test runs: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.client.EXPECT().FindRuns(gomock.Any(), "project", tt.filter).Return(runs, tt.err)
+
+			s := NewService(f.client)
+			got, err := s.ListRuns(context.Background(), "project", tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListRuns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
+			}
+
+			ids := make([]int64, 0, len(got))
+			for _, r := range got {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, ids, tt.want)
+		})
+	}
+}
+
+func TestService_UpdateRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     run.Update
+		isUsed     bool
+		err        error
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:   "success",
+			update: run.Update{Title: "Nightly", Tags: []string{"nightly"}, CustomFields: map[string]string{"3": "main"}},
+			isUsed: true,
 		},
 		{
-			name: "error get test runs",
-			args: args{
-				projectCode: "test",
-				ids:         []int64{1},
-				all:         false,
-				start:       0,
-				end:         0,
-			},
-			argsGetTr: argsGetTr{
-				models: []run.Run{{}},
-				isUsed: true,
-				err:    errors.New("error"),
-			},
-			argsDelTr: argsDelTr{
-				ids:    []int64{},
-				isUsed: false,
-				err:    nil,
-			},
+			name:       "nothing to update",
+			update:     run.Update{},
 			wantErr:    true,
-			errMessage: "failed to get test runs: error",
+			errMessage: "nothing to update",
 		},
 		{
-			name: "error delete test run",
-			args: args{
-				projectCode: "test",
-				ids:         []int64{1},
-				all:         false,
-				start:       0,
-				end:         0,
-			},
-			argsGetTr: argsGetTr{
-				models: []run.Run{{
-					ID: 1,
-				}},
-				isUsed: true,
-				err:    nil,
-			},
-			argsDelTr: argsDelTr{
-				ids:    []int64{1},
-				isUsed: true,
-				err:    errors.New("error"),
-			},
+			name:       "failed to update",
+			update:     run.Update{MilestoneID: 2},
+			isUsed:     true,
+			err:        errors.New("error"),
 			wantErr:    true,
-			errMessage: "failed to delete run with id 1: error",
+			errMessage: "error",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := newFixture(t)
+			if tt.isUsed {
+				f.client.EXPECT().UpdateRun(gomock.Any(), "project", int64(1), tt.update).Return(tt.err)
+			}
 
-			if tt.argsGetTr.isUsed {
-				f.client.EXPECT().GetTestRuns(gomock.Any(), tt.args.projectCode, tt.args.start, tt.args.end).Return(tt.argsGetTr.models, tt.argsGetTr.err)
+			s := NewService(f.client)
+			err := s.UpdateRun(context.Background(), "project", 1, tt.update)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateRun() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if tt.argsDelTr.isUsed {
-				f.client.EXPECT().DeleteTestRun(gomock.Any(), tt.args.projectCode, tt.argsDelTr.ids[0]).Return(tt.argsDelTr.err).Times(len(tt.argsDelTr.ids))
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
 			}
+		})
+	}
+}
 
-			s := NewService(f.client)
+func TestService_AbortRun(t *testing.T) {
+	f := newFixture(t)
+	f.client.EXPECT().AbortRun(gomock.Any(), "project", int64(1)).Return(nil)
+	f.client.EXPECT().AbortRun(gomock.Any(), "project", int64(2)).Return(errors.New("error"))
 
-			if err := s.DeleteRun(context.Background(), tt.args.projectCode, tt.args.ids, tt.args.all, tt.args.start, tt.args.end); err != nil {
-				if !tt.wantErr {
-					t.Errorf("DeleteRun() error = %v, wantErr %v", err, tt.wantErr)
-					return
-				}
+	s := NewService(f.client)
+	if err := s.AbortRun(context.Background(), "project", 1); err != nil {
+		t.Errorf("AbortRun() unexpected error: %v", err)
+	}
+	if err := s.AbortRun(context.Background(), "project", 2); err == nil {
+		t.Error("AbortRun() expected error but got none")
+	}
+}
 
-				assert.Equal(t, tt.errMessage, err.Error())
+func TestService_CloneRun(t *testing.T) {
+	src := run.Run{
+		ID:             1,
+		Title:          "Nightly",
+		Status:         "complete",
+		EnvironmentID:  2,
+		PlanID:         3,
+		Cases:          []int64{10, 11},
+		Configurations: []int64{5},
+	}
+
+	tests := []struct {
+		name       string
+		title      string
+		getErr     error
+		createErr  error
+		wantTitle  string
+		want       int64
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name:      "keep title",
+			wantTitle: "Nightly",
+			want:      7,
+		},
+		{
+			name:      "new title",
+			title:     "Nightly rerun",
+			wantTitle: "Nightly rerun",
+			want:      7,
+		},
+		{
+			name:       "failed to get run",
+			getErr:     errors.New("not found"),
+			wantErr:    true,
+			errMessage: "failed to get run 1: not found",
+		},
+		{
+			name:       "failed to create run",
+			wantTitle:  "Nightly",
+			createErr:  errors.New("error"),
+			wantErr:    true,
+			errMessage: "failed to clone run 1: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.client.EXPECT().GetRunWithCases(gomock.Any(), "project", int64(1)).Return(src, tt.getErr)
+			if tt.getErr == nil {
+				want := src
+				want.Title = tt.wantTitle
+				f.client.EXPECT().CreateRunFrom(gomock.Any(), "project", gomock.Eq(want)).Return(int64(7), tt.createErr)
+			}
+
+			s := NewService(f.client)
+			got, err := s.CloneRun(context.Background(), "project", 1, tt.title)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloneRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.Equal(t, err.Error(), tt.errMessage)
+				return
 			}
+			assert.Equal(t, got, tt.want)
 		})
 	}
 }
@@ -0,0 +1,106 @@
+package tmpl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/qase-tms/qasectl/internal/ci"
+)
+
+// Stats holds the number of results by status in the uploaded report
+type Stats struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+	Blocked int
+	Invalid int
+}
+
+// Data holds the values available in run title and description templates
+type Data struct {
+	// Now is the time qasectl started
+	Now time.Time
+	// Date is Now formatted as 2006-01-02
+	Date string
+	// DateTime is Now formatted as 2006-01-02 15:04:05
+	DateTime    string
+	Provider    string
+	Branch      string
+	Commit      string
+	ShortCommit string
+	CommitURL   string
+	JobURL      string
+	PipelineID  string
+	PullRequest string
+	// Stats is filled when uploading results
+	Stats Stats
+}
+
+// NewData returns the template data for the given time and CI job
+func NewData(now time.Time, info ci.Info) Data {
+	return Data{
+		Now:         now,
+		Date:        now.Format("2006-01-02"),
+		DateTime:    now.Format("2006-01-02 15:04:05"),
+		Provider:    info.Provider,
+		Branch:      info.Branch,
+		Commit:      info.Commit,
+		ShortCommit: info.ShortCommit(),
+		CommitURL:   info.CommitURL,
+		JobURL:      info.JobURL,
+		PipelineID:  info.PipelineID,
+		PullRequest: info.PullRequest,
+	}
+}
+
+// funcs are the functions available in templates
+var funcs = template.FuncMap{
+	"env":     os.Getenv,
+	"default": defaultValue,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+}
+
+// Render executes the text as a Go template with the data.
+// Text without template actions is returned unchanged.
+func Render(name, text string, data Data) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	t, err := Parse(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return b.String(), nil
+}
+
+// Parse parses the text as a Go template with the template functions.
+// Executing it fails on fields missing in the data.
+func Parse(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// defaultValue returns the value or the fallback when the value is empty.
+// It is used like {{.Branch | default "local"}}.
+func defaultValue(fallback, value string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package tmpl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qase-tms/qasectl/internal/ci"
+)
+
+func TestRender(t *testing.T) {
+	t.Setenv("QASE_TEST_SHARD", "2")
+
+	data := NewData(
+		time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC),
+		ci.Info{Provider: ci.GitLabCI, Branch: "main", Commit: "0123456789abcdef", PipelineID: "77", JobURL: "https://ci/jobs/5"},
+	)
+	data.Stats = Stats{Total: 10, Passed: 8, Failed: 2}
+
+	tests := []struct {
+		name       string
+		text       string
+		want       string
+		wantErr    bool
+		errMessage string
+	}{
+		{
+			name: "plain text",
+			text: "Nightly run",
+			want: "Nightly run",
+		},
+		{
+			name: "date and git",
+			text: "Nightly {{.Date}} {{.Branch}}@{{.ShortCommit}}",
+			want: "Nightly 2026-03-14 main@01234567",
+		},
+		{
+			name: "ci and stats",
+			text: "#{{.PipelineID}} {{.Stats.Passed}}/{{.Stats.Total}} passed, see {{.JobURL}}",
+			want: "#77 8/10 passed, see https://ci/jobs/5",
+		},
+		{
+			name: "functions",
+			text: `{{.Now.Format "Jan 2"}} shard {{env "QASE_TEST_SHARD"}} {{.PullRequest | default "no PR"}} {{upper .Branch}}`,
+			want: "Mar 14 shard 2 no PR MAIN",
+		},
+		{
+			name:       "unknown field",
+			text:       "{{.Build}}",
+			wantErr:    true,
+			errMessage: "failed to render title template: template: title:1:2: executing \"title\" at <.Build>: can't evaluate field Build in type tmpl.Data",
+		},
+		{
+			name:       "invalid template",
+			text:       "{{.Date",
+			wantErr:    true,
+			errMessage: "failed to parse title template: template: title:1: unclosed action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render("title", tt.text, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if err.Error() != tt.errMessage {
+					t.Errorf("Render() error = %v, want %v", err, tt.errMessage)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}